@@ -0,0 +1,147 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v3
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"time"
+)
+
+// exportedLeg is Leg's JSON/IPFIX-friendly shape: a bitmask of booleans spelled out as named
+// fields, rather than Leg.Flags()'s packed uint32, so a `calicoctl bpf conntrack watch` consumer
+// (or anything downstream of its JSON output, e.g. a flow-log shipper) doesn't need this
+// package's bit layout to read it.
+type exportedLeg struct {
+	Bytes    uint64 `json:"bytes"`
+	Packets  uint32 `json:"packets"`
+	SynSeen  bool   `json:"synSeen"`
+	AckSeen  bool   `json:"ackSeen"`
+	FinSeen  bool   `json:"finSeen"`
+	RstSeen  bool   `json:"rstSeen"`
+	Approved bool   `json:"approved"`
+	Workload bool   `json:"workload"`
+	Ifindex  uint32 `json:"ifindex,omitempty"`
+}
+
+func exportLeg(leg Leg) exportedLeg {
+	return exportedLeg{
+		Bytes:    leg.Bytes,
+		Packets:  leg.Packets,
+		SynSeen:  leg.SynSeen,
+		AckSeen:  leg.AckSeen,
+		FinSeen:  leg.FinSeen,
+		RstSeen:  leg.RstSeen,
+		Approved: leg.Approved,
+		Workload: leg.Workload,
+		Ifindex:  leg.Ifindex,
+	}
+}
+
+// exportedEvent is the on-the-wire JSON shape of an Event: the Key/Value decoded into named,
+// human- and machine-readable fields (addresses as strings, the NAT type spelled out) instead of
+// the packed byte arrays Key/Value are stored as, in the same spirit as IPFIX records decoding a
+// flow into named information elements rather than shipping the raw template bytes.
+type exportedEvent struct {
+	Type      EventType   `json:"type"`
+	Time      time.Time   `json:"time"`
+	Proto     uint8       `json:"proto"`
+	AddrA     string      `json:"addrA"`
+	PortA     uint16      `json:"portA"`
+	AddrB     string      `json:"addrB"`
+	PortB     uint16      `json:"portB"`
+	NATType   string      `json:"natType"`
+	OrigDst   string      `json:"origDst,omitempty"`
+	OrigSrc   string      `json:"origSrc,omitempty"`
+	OrigPort  uint16      `json:"origPort,omitempty"`
+	OrigSPort uint16      `json:"origSPort,omitempty"`
+	TunIP     string      `json:"tunnelIP,omitempty"`
+	A2B       exportedLeg `json:"a2b"`
+	B2A       exportedLeg `json:"b2a"`
+}
+
+func natTypeName(t uint8) string {
+	switch t {
+	case TypeNATForward:
+		return "nat-forward"
+	case TypeNATReverse:
+		return "nat-reverse"
+	default:
+		return "normal"
+	}
+}
+
+func ipString(ip net.IP) string {
+	if ip == nil || ip.IsUnspecified() {
+		return ""
+	}
+	return ip.String()
+}
+
+func toExportedEvent(ev Event) exportedEvent {
+	data := ev.Data
+	return exportedEvent{
+		Type:      ev.Type,
+		Time:      ev.Time,
+		Proto:     ev.Key.Proto(),
+		AddrA:     ev.Key.AddrA().String(),
+		PortA:     ev.Key.PortA(),
+		AddrB:     ev.Key.AddrB().String(),
+		PortB:     ev.Key.PortB(),
+		NATType:   natTypeName(ev.Value.Type()),
+		OrigDst:   ipString(data.OrigDst),
+		OrigSrc:   ipString(data.OrigSrc),
+		OrigPort:  data.OrigPort,
+		OrigSPort: data.OrigSPort,
+		TunIP:     ipString(data.TunIP),
+		A2B:       exportLeg(data.A2B),
+		B2A:       exportLeg(data.B2A),
+	}
+}
+
+// JSONExporter writes Events to an io.Writer as newline-delimited JSON, one object per line, the
+// shape `calicoctl bpf conntrack watch` (or any other consumer that just wants line-oriented JSON
+// it can pipe into jq or a log shipper) streams to stdout.
+type JSONExporter struct {
+	w   io.Writer
+	enc *json.Encoder
+}
+
+// NewJSONExporter builds a JSONExporter writing to w.
+func NewJSONExporter(w io.Writer) *JSONExporter {
+	return &JSONExporter{w: w, enc: json.NewEncoder(w)}
+}
+
+// Export writes ev to the underlying writer as one JSON object followed by a newline.
+func (e *JSONExporter) Export(ev Event) error {
+	if err := e.enc.Encode(toExportedEvent(ev)); err != nil {
+		return fmt.Errorf("encoding conntrack event: %w", err)
+	}
+	return nil
+}
+
+// Run reads from events until it's closed (or ctx, if passed a channel fed by Watcher.Watch,
+// stops producing any), exporting each one in turn. It returns the first Export error
+// encountered, having already stopped reading further events.
+func (e *JSONExporter) Run(events <-chan Event) error {
+	for ev := range events {
+		if err := e.Export(ev); err != nil {
+			return err
+		}
+	}
+	return nil
+}
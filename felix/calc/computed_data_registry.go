@@ -0,0 +1,122 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package calc
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/model"
+)
+
+// ComputedDataSink is what a ComputedDataProducer pushes its updates to. ComputedDataRegistry is
+// the only production implementation; a producer never talks to a PolicyResolver directly, so
+// that the registry can enforce the one-producer-per-kind invariant and track outstanding entries
+// for Shutdown regardless of which producer emitted them.
+type ComputedDataSink interface {
+	OnComputedDataUpdate(endpointKey model.EndpointKey, kind EndpointComputedDataKind, data EndpointComputedData)
+}
+
+// ComputedDataProducer lets a subsystem (KubeVirt VM metadata, BGP peer data, a service-account
+// token watcher, node-local-DNS, ...) contribute one kind of EndpointComputedData without
+// PolicyResolver needing to know it exists. Register it with a ComputedDataRegistry; the registry
+// calls Subscribe once, at registration time, with the sink the producer should push updates to.
+type ComputedDataProducer interface {
+	// Kind returns the EndpointComputedDataKind this producer is the sole source of. Two
+	// producers registered for the same Kind is a programming error (see
+	// ComputedDataRegistry.Register), not a runtime condition to recover from.
+	Kind() EndpointComputedDataKind
+
+	// Subscribe gives the producer the sink to push OnComputedDataUpdate calls to. Called once,
+	// synchronously, from Register, before Start.
+	Subscribe(sink ComputedDataSink)
+
+	// Start begins whatever background work the producer needs (e.g. watching an informer) and
+	// returns once it's ready to start emitting updates, or ctx is cancelled. A producer that's
+	// purely reactive -- driven by OnUpdate calls a caller feeds it directly, like
+	// KubeVirtVMWatcher -- can return nil immediately; it has nothing to start.
+	Start(ctx context.Context) error
+}
+
+// ComputedDataRegistry is the ComputedDataSink PolicyResolver owns: every ComputedDataProducer
+// registers with it at startup, and the registry is what actually calls
+// PolicyResolver.OnEndpointComputedDataUpdate. This exists so that adding a new kind of
+// EndpointComputedData is a matter of writing a new ComputedDataProducer and registering it,
+// rather than adding another direct caller of OnEndpointComputedDataUpdate to PolicyResolver.
+type ComputedDataRegistry struct {
+	resolver *PolicyResolver
+
+	// order is the registration order of each Kind seen so far, assigned on first Register call
+	// for that Kind. PolicyResolver uses it (via setComputedDataOrder) to materialize each
+	// endpoint's []EndpointComputedData slice deterministically -- in registration order --
+	// rather than in the non-deterministic order of a map iteration, which is also why a Kind
+	// with computed data but no Register call (e.g. a test driving OnEndpointComputedDataUpdate
+	// directly) falls back to alphabetical-by-Kind ordering instead.
+	order map[EndpointComputedDataKind]int
+
+	// outstanding tracks, per Kind, every endpoint this registry has most recently pushed a
+	// non-nil update for, so Shutdown knows exactly which (endpoint, kind) pairs to nil out.
+	outstanding map[EndpointComputedDataKind]map[model.EndpointKey]bool
+}
+
+// NewComputedDataRegistry builds a registry that forwards every producer's updates to resolver.
+func NewComputedDataRegistry(resolver *PolicyResolver) *ComputedDataRegistry {
+	return &ComputedDataRegistry{
+		resolver:    resolver,
+		order:       map[EndpointComputedDataKind]int{},
+		outstanding: map[EndpointComputedDataKind]map[model.EndpointKey]bool{},
+	}
+}
+
+// Register adds producer to the registry, panicking if another producer has already been
+// registered for the same Kind -- the one-producer-per-kind invariant this chunk requires, since
+// two producers racing to own the same Kind is a wiring bug to catch at startup, not a runtime
+// condition either producer could sensibly recover from.
+func (r *ComputedDataRegistry) Register(producer ComputedDataProducer) {
+	kind := producer.Kind()
+	if _, exists := r.order[kind]; exists {
+		panic(fmt.Sprintf("calc: two ComputedDataProducers registered for kind %q", kind))
+	}
+	r.order[kind] = len(r.order)
+	r.resolver.setComputedDataOrder(kind, r.order[kind])
+	producer.Subscribe(r)
+}
+
+// OnComputedDataUpdate implements ComputedDataSink. It records kind/endpointKey as outstanding
+// (or clears it, for a nil update) before forwarding to the resolver, so Shutdown can find it.
+func (r *ComputedDataRegistry) OnComputedDataUpdate(endpointKey model.EndpointKey, kind EndpointComputedDataKind, data EndpointComputedData) {
+	if data == nil {
+		delete(r.outstanding[kind], endpointKey)
+	} else {
+		if r.outstanding[kind] == nil {
+			r.outstanding[kind] = map[model.EndpointKey]bool{}
+		}
+		r.outstanding[kind][endpointKey] = true
+	}
+	r.resolver.OnEndpointComputedDataUpdate(endpointKey, kind, data)
+}
+
+// Shutdown fires a nil update for every (endpoint, kind) pair this registry has an outstanding
+// non-nil entry for, so a graceful process shutdown leaves PolicyResolver's computed data (and
+// anything downstream derived from it, e.g. a dataplane render) consistent with "nothing is
+// running any more" rather than holding stale last-known values.
+func (r *ComputedDataRegistry) Shutdown() {
+	for kind, endpoints := range r.outstanding {
+		for endpointKey := range endpoints {
+			r.resolver.OnEndpointComputedDataUpdate(endpointKey, kind, nil)
+		}
+		delete(r.outstanding, kind)
+	}
+}
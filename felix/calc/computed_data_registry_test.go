@@ -0,0 +1,111 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package calc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/api"
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/model"
+)
+
+// fakeComputedDataProducer is a minimal ComputedDataProducer for tests that don't need
+// KubeVirtVMWatcher's own behaviour, just the registry's.
+type fakeComputedDataProducer struct {
+	kind EndpointComputedDataKind
+	sink ComputedDataSink
+}
+
+func (f *fakeComputedDataProducer) Kind() EndpointComputedDataKind  { return f.kind }
+func (f *fakeComputedDataProducer) Subscribe(sink ComputedDataSink) { f.sink = sink }
+func (f *fakeComputedDataProducer) Start(ctx context.Context) error { return nil }
+func (f *fakeComputedDataProducer) emit(key model.EndpointKey, d EndpointComputedData) {
+	f.sink.OnComputedDataUpdate(key, f.kind, d)
+}
+
+func TestComputedDataRegistry_PanicsOnDuplicateKind(t *testing.T) {
+	pr, _ := createPolicyResolver()
+	registry := NewComputedDataRegistry(pr)
+	registry.Register(&fakeComputedDataProducer{kind: testComputedDataKindA})
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected Register to panic on a duplicate kind")
+		}
+	}()
+	registry.Register(&fakeComputedDataProducer{kind: testComputedDataKindA})
+}
+
+// TestComputedDataRegistry_OrderedMaterialization registers two producers in a given order and
+// asserts a Flush materializes their data in that same order, regardless of which one actually
+// emitted most recently.
+func TestComputedDataRegistry_OrderedMaterialization(t *testing.T) {
+	pr, recorder := createPolicyResolver()
+	pr.OnDatamodelStatus(api.InSync)
+	registry := NewComputedDataRegistry(pr)
+
+	producerB := &fakeComputedDataProducer{kind: testComputedDataKindB}
+	producerA := &fakeComputedDataProducer{kind: testComputedDataKindA}
+	// Register B before A, so registration order is the opposite of alphabetical order.
+	registry.Register(producerB)
+	registry.Register(producerA)
+
+	endpointKey := model.WorkloadEndpointKey{Hostname: "host1"}
+	wep := &model.WorkloadEndpoint{Name: "we1"}
+	pr.endpoints[endpointKey] = wep
+	polKey := model.PolicyKey{Name: "test-policy"}
+	pr.allPolicies[polKey] = ExtractPolicyMetadata(&model.Policy{Tier: "default"})
+	pr.OnPolicyMatch(polKey, endpointKey)
+
+	cdA := &testComputedData{Value: "a"}
+	cdB := &testComputedData{Value: "b"}
+	producerA.emit(endpointKey, cdA)
+	producerB.emit(endpointKey, cdB)
+	pr.Flush()
+
+	if len(recorder.updates) != 1 || len(recorder.updates[0].ComputedData) != 2 {
+		t.Fatalf("expected 1 update with 2 computed data entries, got %+v", recorder.updates)
+	}
+	if recorder.updates[0].ComputedData[0].(*testComputedData) != cdB {
+		t.Errorf("expected ComputedData[0] to be cdB (registered first), got %v", recorder.updates[0].ComputedData[0])
+	}
+	if recorder.updates[0].ComputedData[1].(*testComputedData) != cdA {
+		t.Errorf("expected ComputedData[1] to be cdA (registered second), got %v", recorder.updates[0].ComputedData[1])
+	}
+}
+
+// TestComputedDataRegistry_ShutdownClearsOutstanding asserts Shutdown fires a nil update for
+// every endpoint a producer last emitted non-nil data for.
+func TestComputedDataRegistry_ShutdownClearsOutstanding(t *testing.T) {
+	pr, _ := createPolicyResolver()
+	pr.OnDatamodelStatus(api.InSync)
+	registry := NewComputedDataRegistry(pr)
+	producer := &fakeComputedDataProducer{kind: testComputedDataKindA}
+	registry.Register(producer)
+
+	endpointKey := model.WorkloadEndpointKey{Hostname: "host1"}
+	pr.endpoints[endpointKey] = &model.WorkloadEndpoint{Name: "we1"}
+
+	producer.emit(endpointKey, &testComputedData{Value: "a"})
+	if _, exists := pr.endpointComputedData[endpointKey][testComputedDataKindA]; !exists {
+		t.Fatal("expected computed data to be recorded before shutdown")
+	}
+
+	registry.Shutdown()
+	if _, exists := pr.endpointComputedData[endpointKey][testComputedDataKindA]; exists {
+		t.Error("expected Shutdown to clear outstanding computed data")
+	}
+}
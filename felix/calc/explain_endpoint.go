@@ -0,0 +1,94 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package calc
+
+import (
+	"sort"
+
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/model"
+)
+
+// EndpointExplanation is ActiveRulesCalculator.ExplainEndpoint's point-in-time snapshot of why a
+// given endpoint is in the active set it is: every policy currently matched against it (via its
+// own selector), every computed selector (see AddExtraComputedSelector) currently matched against
+// it, and every profile directly assigned to it. Like the rest of the calc graph, ARC processes
+// one update at a time on a single goroutine, so a caller on that same goroutine (e.g. a debug
+// handler invoked from OnUpdate) always sees a result consistent with the last processed update,
+// with no locking required.
+type EndpointExplanation struct {
+	EndpointKey              model.EndpointKey
+	MatchedPolicies          []model.PolicyKey
+	MatchedProfiles          []model.ProfileRulesKey
+	MatchedComputedSelectors []string
+}
+
+// ExplainEndpoint answers "which policies, profiles, and computed selectors match this endpoint,
+// and why?" without re-evaluating any selector: it's a read of the indexes ARC already maintains.
+// endpointKeyToPolicyIDs and endpointKeyToComputedSelectors are the reverse of ARC's existing
+// policyIDToEndpointKeys forward index (a multidict.IfaceToIface, same as policyIDToEndpointKeys),
+// added so this costs O(matches for that endpoint) rather than a scan of every active policy; both
+// are kept in lockstep with the forward index wherever a match starts or stops.
+func (arc *ActiveRulesCalculator) ExplainEndpoint(key model.EndpointKey) EndpointExplanation {
+	exp := EndpointExplanation{EndpointKey: key}
+	arc.endpointKeyToPolicyIDs.Iter(key, func(v interface{}) {
+		exp.MatchedPolicies = append(exp.MatchedPolicies, v.(model.PolicyKey))
+	})
+	arc.endpointKeyToComputedSelectors.Iter(key, func(v interface{}) {
+		exp.MatchedComputedSelectors = append(exp.MatchedComputedSelectors, v.(string))
+	})
+	if wepKey, ok := key.(model.WorkloadEndpointKey); ok {
+		if ep, ok := arc.endpoints[wepKey]; ok {
+			for _, profileID := range ep.ProfileIDs {
+				exp.MatchedProfiles = append(exp.MatchedProfiles, model.ProfileRulesKey{ProfileKey: model.ProfileKey{Name: profileID}})
+			}
+		}
+	}
+
+	sort.Slice(exp.MatchedPolicies, func(i, j int) bool {
+		return exp.MatchedPolicies[i].Name < exp.MatchedPolicies[j].Name
+	})
+	sort.Strings(exp.MatchedComputedSelectors)
+	return exp
+}
+
+// ResolvedEndpointExplanation is PolicyResolver.ExplainEndpoint's snapshot: it adds the tier
+// ordering PolicyResolver computes (the same ordering a Flush would have emitted as part of an
+// endpoint's TierInfo) and the kinds of EndpointComputedData currently attached, so a caller can
+// see both "what matched" and "what PolicyResolver resolved that into" in one call. It
+// deliberately doesn't repeat EndpointExplanation's per-selector detail -- PolicyResolver doesn't
+// itself track which selector matched, only which policies did, via ActiveRulesCalculator -- so a
+// caller wanting both calls ActiveRulesCalculator.ExplainEndpoint too.
+type ResolvedEndpointExplanation struct {
+	EndpointKey       model.EndpointKey
+	Tiers             []TierInfo
+	ComputedDataKinds []EndpointComputedDataKind
+}
+
+// ExplainEndpoint mirrors ActiveRulesCalculator.ExplainEndpoint at the PolicyResolver layer. Its
+// Tiers come from lastTierInfo -- the TierInfo slice most recently handed to OnEndpointTierUpdate
+// for this endpoint, cached there for exactly this query rather than recomputed via policySorter --
+// so they reflect the state as of the last Flush. ComputedDataKinds are read from
+// endpointComputedData directly, so they also include computed data updates not yet flushed.
+func (pr *PolicyResolver) ExplainEndpoint(key model.EndpointKey) ResolvedEndpointExplanation {
+	exp := ResolvedEndpointExplanation{EndpointKey: key}
+	exp.Tiers = pr.lastTierInfo[key]
+	for kind := range pr.endpointComputedData[key] {
+		exp.ComputedDataKinds = append(exp.ComputedDataKinds, kind)
+	}
+	sort.Slice(exp.ComputedDataKinds, func(i, j int) bool {
+		return exp.ComputedDataKinds[i] < exp.ComputedDataKinds[j]
+	})
+	return exp
+}
@@ -0,0 +1,97 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package calc
+
+import (
+	"testing"
+
+	v3 "github.com/projectcalico/api/pkg/apis/projectcalico/v3"
+
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/api"
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/model"
+)
+
+// TestActiveRulesCalculator_ExplainEndpoint seeds a mix of a policy match, a computed selector
+// match, and a profile assignment, then asserts ExplainEndpoint's snapshot reports all three and
+// is stable across repeated calls (i.e. read-only).
+func TestActiveRulesCalculator_ExplainEndpoint(t *testing.T) {
+	arc, _ := createARC()
+
+	arc.AddExtraComputedSelector("has(foo)")
+
+	epKey := model.WorkloadEndpointKey{Hostname: "host1", OrchestratorID: "orch", WorkloadID: "wl1", EndpointID: "ep1"}
+	addEndpoint(arc, epKey, map[string]string{"foo": "bar"})
+	arc.endpoints[epKey].ProfileIDs = []string{"prof-a"}
+
+	polKey := model.PolicyKey{Name: "test-policy", Kind: v3.KindNetworkPolicy}
+	arc.OnPolicyMatch(polKey, epKey)
+
+	for i := 0; i < 2; i++ {
+		exp := arc.ExplainEndpoint(epKey)
+		if len(exp.MatchedPolicies) != 1 || exp.MatchedPolicies[0] != polKey {
+			t.Fatalf("expected 1 matched policy %v, got %v", polKey, exp.MatchedPolicies)
+		}
+		if len(exp.MatchedComputedSelectors) != 1 || exp.MatchedComputedSelectors[0] != "has(foo)" {
+			t.Fatalf("expected 1 matched computed selector, got %v", exp.MatchedComputedSelectors)
+		}
+		if len(exp.MatchedProfiles) != 1 || exp.MatchedProfiles[0].Name != "prof-a" {
+			t.Fatalf("expected 1 matched profile prof-a, got %v", exp.MatchedProfiles)
+		}
+	}
+
+	deleteEndpoint(arc, epKey)
+	exp := arc.ExplainEndpoint(epKey)
+	if len(exp.MatchedPolicies) != 0 || len(exp.MatchedComputedSelectors) != 0 || len(exp.MatchedProfiles) != 0 {
+		t.Errorf("expected empty explanation after endpoint delete, got %+v", exp)
+	}
+}
+
+// TestPolicyResolver_ExplainEndpoint extends the policyResolverRecorder pattern: it seeds a
+// policy match and a computed data entry, flushes, and asserts ExplainEndpoint's snapshot matches
+// what the recorder itself observed.
+func TestPolicyResolver_ExplainEndpoint(t *testing.T) {
+	pr, recorder := createPolicyResolver()
+	pr.OnDatamodelStatus(api.InSync)
+
+	endpointKey := model.WorkloadEndpointKey{Hostname: "host1"}
+	wep := &model.WorkloadEndpoint{Name: "we1"}
+	pr.endpoints[endpointKey] = wep
+
+	polKey := model.PolicyKey{Name: "test-policy", Kind: v3.KindNetworkPolicy}
+	pol := ExtractPolicyMetadata(&model.Policy{Tier: "default"})
+	pr.allPolicies[polKey] = pol
+	pr.OnPolicyMatch(polKey, endpointKey)
+
+	pr.OnEndpointComputedDataUpdate(endpointKey, testComputedDataKindA, &testComputedData{Value: "v1"})
+	pr.Flush()
+
+	if len(recorder.updates) != 1 {
+		t.Fatalf("expected 1 flush update, got %d", len(recorder.updates))
+	}
+
+	exp := pr.ExplainEndpoint(endpointKey)
+	if len(exp.Tiers) != 1 || len(exp.Tiers[0].OrderedPolicies) != 1 || exp.Tiers[0].OrderedPolicies[0].Key != polKey {
+		t.Fatalf("expected explanation tiers to match the flushed tiers, got %+v", exp.Tiers)
+	}
+	if len(exp.ComputedDataKinds) != 1 || exp.ComputedDataKinds[0] != testComputedDataKindA {
+		t.Fatalf("expected explanation to report testComputedDataKindA, got %v", exp.ComputedDataKinds)
+	}
+
+	// A second, read-only call must return the same snapshot without needing another Flush.
+	exp2 := pr.ExplainEndpoint(endpointKey)
+	if len(exp2.Tiers) != len(exp.Tiers) || len(exp2.ComputedDataKinds) != len(exp.ComputedDataKinds) {
+		t.Errorf("expected repeated ExplainEndpoint calls to be stable, got %+v then %+v", exp, exp2)
+	}
+}
@@ -0,0 +1,272 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package calc
+
+import (
+	"context"
+	"strings"
+
+	kubevirtv1 "kubevirt.io/api/core/v1"
+
+	"github.com/projectcalico/calico/felix/proto"
+	"github.com/projectcalico/calico/libcalico-go/lib/apis/internalapi"
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/api"
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/model"
+)
+
+// KindKubeVirtVM is the EndpointComputedDataKind KubeVirtVMWatcher emits: the owning
+// VirtualMachine's name, labels, annotations, and printable status (e.g. "Migrating"), stamped onto
+// every WorkloadEndpoint backed by one of that VM's launcher pods. This lets policy select on
+// VM-level identity (e.g. "kubevirt.io/vm == db-primary") instead of only the launcher pod's
+// own, migration-churned labels.
+const KindKubeVirtVM EndpointComputedDataKind = "KubeVirtVM"
+
+// domainLabel is the label KubeVirt's virt-handler stamps on every launcher pod it creates,
+// naming the VirtualMachineInstance (and transitively, via its owner reference, the
+// VirtualMachine) the pod backs.
+const domainLabel = "kubevirt.io/domain"
+
+// kubeVirtVMComputedData is the KindKubeVirtVM payload. It's immutable once constructed; a
+// change to the VM, or to the current migration phase, produces a replacement value via
+// OnEndpointComputedDataUpdate rather than mutating this one in place.
+type kubeVirtVMComputedData struct {
+	vmName         string
+	vmLabels       map[string]string
+	vmAnnotations  map[string]string
+	migrationPhase string
+}
+
+func (d *kubeVirtVMComputedData) ApplyTo(wep *proto.WorkloadEndpoint) {
+	if wep.Labels == nil {
+		wep.Labels = map[string]string{}
+	}
+	wep.Labels["kubevirt.io/vm"] = d.vmName
+	for k, v := range d.vmLabels {
+		wep.Labels[k] = v
+	}
+	if len(d.vmAnnotations) == 0 && d.migrationPhase == "" {
+		return
+	}
+	if wep.Annotations == nil {
+		wep.Annotations = map[string]string{}
+	}
+	for k, v := range d.vmAnnotations {
+		wep.Annotations[k] = v
+	}
+	if d.migrationPhase != "" {
+		wep.Annotations["kubevirt.io/migration-phase"] = d.migrationPhase
+	}
+}
+
+// KubeVirtVMWatcher keeps KindKubeVirtVM computed data in sync with the VirtualMachine,
+// VirtualMachineInstance, and WorkloadEndpoint updates the syncer delivers. It implements the
+// same api.Update-driven OnUpdate contract PolicyResolver itself registers for (see
+// NewPolicyResolver / RegisterCallback), so it's wired in as just another datamodel consumer
+// rather than bolted onto PolicyResolver's internals. It also implements ComputedDataProducer,
+// so it's registered with a ComputedDataRegistry rather than holding a *PolicyResolver directly;
+// it's purely reactive (driven by OnUpdate, not a background goroutine of its own), so Start is a
+// no-op.
+type KubeVirtVMWatcher struct {
+	sink ComputedDataSink
+
+	// vms indexes the last-known VirtualMachine, keyed by its namespaced name, so a VMI or WEP
+	// update that arrives before (or without) a fresh VM update can still be enriched from
+	// what we already know about the VM.
+	vms map[model.ResourceKey]*kubevirtv1.VirtualMachine
+
+	// vmiToVM maps a VirtualMachineInstance's key to the VirtualMachine that owns it, derived
+	// from the VMI's OwnerReferences. Needed to resolve a VM deletion, and a VMI rename across
+	// migration, back to the right set of endpoints.
+	vmiToVM map[model.ResourceKey]model.ResourceKey
+
+	// podToVMI maps a launcher pod's key (identified by its domainLabel, which names the VMI)
+	// to the VMI's key, so a WorkloadEndpoint update -- which carries only the pod's
+	// name/namespace/labels, not the VMI or VM -- can be traced back to the owning VM.
+	podToVMI map[model.EndpointKey]model.ResourceKey
+
+	// endpointsByVMI tracks which WorkloadEndpoint keys are currently stamped from which VMI's
+	// VM data, so a VM/VMI deletion or rename knows exactly which endpoints to clear or
+	// re-stamp, per this chunk's cleanup contract.
+	endpointsByVMI map[model.ResourceKey]map[model.EndpointKey]bool
+}
+
+// NewKubeVirtVMWatcher builds a watcher that publishes KindKubeVirtVM computed data. Register it
+// with a ComputedDataRegistry, then register it with the syncer the same way any other
+// api.Update-driven consumer is registered.
+func NewKubeVirtVMWatcher() *KubeVirtVMWatcher {
+	return &KubeVirtVMWatcher{
+		vms:            map[model.ResourceKey]*kubevirtv1.VirtualMachine{},
+		vmiToVM:        map[model.ResourceKey]model.ResourceKey{},
+		podToVMI:       map[model.EndpointKey]model.ResourceKey{},
+		endpointsByVMI: map[model.ResourceKey]map[model.EndpointKey]bool{},
+	}
+}
+
+// Kind implements ComputedDataProducer.
+func (w *KubeVirtVMWatcher) Kind() EndpointComputedDataKind { return KindKubeVirtVM }
+
+// Subscribe implements ComputedDataProducer.
+func (w *KubeVirtVMWatcher) Subscribe(sink ComputedDataSink) { w.sink = sink }
+
+// Start implements ComputedDataProducer. KubeVirtVMWatcher has no background work of its own --
+// it only reacts to OnUpdate calls a caller feeds it from the syncer -- so there's nothing to
+// start.
+func (w *KubeVirtVMWatcher) Start(ctx context.Context) error { return nil }
+
+func (w *KubeVirtVMWatcher) OnUpdate(update api.Update) {
+	switch key := update.Key.(type) {
+	case model.ResourceKey:
+		switch key.Kind {
+		case internalapi.KindKubeVirtVM:
+			w.onVMUpdate(key, update.Value)
+		case internalapi.KindKubeVirtVMI:
+			w.onVMIUpdate(key, update.Value)
+		}
+	case model.WorkloadEndpointKey:
+		w.onWorkloadEndpointUpdate(key, update.Value)
+	}
+}
+
+func (w *KubeVirtVMWatcher) onVMUpdate(key model.ResourceKey, value any) {
+	if value == nil {
+		delete(w.vms, key)
+		w.clearVM(key)
+		return
+	}
+	vm := value.(*kubevirtv1.VirtualMachine)
+	w.vms[key] = vm
+	w.restampVM(key, vm)
+}
+
+// onVMIUpdate re-derives the VMI-to-VM mapping whenever a VMI appears, disappears, or is
+// recreated under the same name with a different owner (the rename-across-migration case
+// called out in this chunk: the old VMI's endpoints are cleared and the new VMI's endpoints are
+// re-stamped from its owning VM).
+func (w *KubeVirtVMWatcher) onVMIUpdate(key model.ResourceKey, value any) {
+	oldVMKey, hadVM := w.vmiToVM[key]
+	if value == nil {
+		delete(w.vmiToVM, key)
+		if hadVM {
+			w.clearVMIEndpoints(key)
+		}
+		return
+	}
+	vmi := value.(*kubevirtv1.VirtualMachineInstance)
+	newVMKey, ok := ownerVMKey(vmi)
+	if !ok {
+		delete(w.vmiToVM, key)
+		if hadVM {
+			w.clearVMIEndpoints(key)
+		}
+		return
+	}
+	w.vmiToVM[key] = newVMKey
+	if hadVM && oldVMKey != newVMKey {
+		w.clearVMIEndpoints(key)
+	}
+	if vm, ok := w.vms[newVMKey]; ok {
+		w.restampVMIEndpoints(key, newVMKey, vm)
+	}
+}
+
+func (w *KubeVirtVMWatcher) onWorkloadEndpointUpdate(key model.WorkloadEndpointKey, value any) {
+	epKey := model.EndpointKey(key)
+	if value == nil {
+		if vmiKey, ok := w.podToVMI[epKey]; ok {
+			delete(w.endpointsByVMI[vmiKey], epKey)
+			delete(w.podToVMI, epKey)
+		}
+		w.sink.OnComputedDataUpdate(epKey, KindKubeVirtVM, nil)
+		return
+	}
+	wep := value.(*model.WorkloadEndpoint)
+	domain, ok := wep.Labels[domainLabel]
+	if !ok {
+		return
+	}
+	// A Kubernetes WorkloadID is "<namespace>/<pod name>".
+	namespace, _, _ := strings.Cut(key.WorkloadID, "/")
+	vmiKey := model.ResourceKey{Kind: internalapi.KindKubeVirtVMI, Namespace: namespace, Name: domain}
+	w.podToVMI[epKey] = vmiKey
+	if w.endpointsByVMI[vmiKey] == nil {
+		w.endpointsByVMI[vmiKey] = map[model.EndpointKey]bool{}
+	}
+	w.endpointsByVMI[vmiKey][epKey] = true
+
+	vmKey, ok := w.vmiToVM[vmiKey]
+	if !ok {
+		return
+	}
+	vm, ok := w.vms[vmKey]
+	if !ok {
+		return
+	}
+	w.sink.OnComputedDataUpdate(epKey, KindKubeVirtVM, computedDataForVM(vm))
+}
+
+// restampVM re-stamps every endpoint backed by any VMI currently attributed to vmKey, e.g. after
+// the VM's labels/annotations or migration phase change.
+func (w *KubeVirtVMWatcher) restampVM(vmKey model.ResourceKey, vm *kubevirtv1.VirtualMachine) {
+	for vmiKey, owner := range w.vmiToVM {
+		if owner == vmKey {
+			w.restampVMIEndpoints(vmiKey, vmKey, vm)
+		}
+	}
+}
+
+func (w *KubeVirtVMWatcher) restampVMIEndpoints(vmiKey, vmKey model.ResourceKey, vm *kubevirtv1.VirtualMachine) {
+	cd := computedDataForVM(vm)
+	for epKey := range w.endpointsByVMI[vmiKey] {
+		w.sink.OnComputedDataUpdate(epKey, KindKubeVirtVM, cd)
+	}
+}
+
+// clearVM fires a nil update for every endpoint attributed to vmKey -- the VM-deletion cleanup
+// path this chunk calls out.
+func (w *KubeVirtVMWatcher) clearVM(vmKey model.ResourceKey) {
+	for vmiKey, owner := range w.vmiToVM {
+		if owner == vmKey {
+			w.clearVMIEndpoints(vmiKey)
+		}
+	}
+}
+
+func (w *KubeVirtVMWatcher) clearVMIEndpoints(vmiKey model.ResourceKey) {
+	for epKey := range w.endpointsByVMI[vmiKey] {
+		w.sink.OnComputedDataUpdate(epKey, KindKubeVirtVM, nil)
+	}
+	delete(w.endpointsByVMI, vmiKey)
+}
+
+// computedDataForVM takes migrationPhase from the VM's Status.PrintableStatus (e.g. Running,
+// Migrating, Stopped); the phase of a VirtualMachineInstanceMigration is not read.
+func computedDataForVM(vm *kubevirtv1.VirtualMachine) *kubeVirtVMComputedData {
+	return &kubeVirtVMComputedData{
+		vmName:         vm.Name,
+		vmLabels:       vm.Labels,
+		vmAnnotations:  vm.Annotations,
+		migrationPhase: string(vm.Status.PrintableStatus),
+	}
+}
+
+// ownerVMKey returns vmi's owning VirtualMachine's key, if it has one.
+func ownerVMKey(vmi *kubevirtv1.VirtualMachineInstance) (model.ResourceKey, bool) {
+	for _, ref := range vmi.OwnerReferences {
+		if ref.Kind == "VirtualMachine" {
+			return model.ResourceKey{Kind: internalapi.KindKubeVirtVM, Namespace: vmi.Namespace, Name: ref.Name}, true
+		}
+	}
+	return model.ResourceKey{}, false
+}
@@ -0,0 +1,131 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package calc
+
+import (
+	"testing"
+
+	v3 "github.com/projectcalico/api/pkg/apis/projectcalico/v3"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubevirtv1 "kubevirt.io/api/core/v1"
+
+	"github.com/projectcalico/calico/libcalico-go/lib/apis/internalapi"
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/api"
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/model"
+)
+
+func update(key model.Key, value any) api.Update {
+	u := api.Update{}
+	u.Key = key
+	u.Value = value
+	return u
+}
+
+// TestKubeVirtVMWatcher_ComputedDataThreadedThroughFlush drives a KubeVirtVMWatcher with a VM,
+// its VMI, and a launcher-pod WorkloadEndpoint, and asserts the VM's metadata reaches a Flush
+// the same way TestPolicyResolver_ComputedDataIncludedInFlush asserts for a hand-built
+// EndpointComputedData.
+func TestKubeVirtVMWatcher_ComputedDataThreadedThroughFlush(t *testing.T) {
+	pr, recorder := createPolicyResolver()
+	pr.OnDatamodelStatus(api.InSync)
+	registry := NewComputedDataRegistry(pr)
+	w := NewKubeVirtVMWatcher()
+	registry.Register(w)
+
+	endpointKey := model.WorkloadEndpointKey{Hostname: "host1", WorkloadID: "default/virt-launcher-db-primary-abcde"}
+	wep := &model.WorkloadEndpoint{Name: "we1", Labels: map[string]string{domainLabel: "db-primary"}}
+	pr.endpoints[endpointKey] = wep
+
+	polKey := model.PolicyKey{Name: "test-policy", Kind: v3.KindNetworkPolicy}
+	pr.allPolicies[polKey] = ExtractPolicyMetadata(&model.Policy{Tier: "default"})
+	pr.OnPolicyMatch(polKey, endpointKey)
+
+	vmKey := model.ResourceKey{Kind: internalapi.KindKubeVirtVM, Namespace: "default", Name: "db-primary"}
+	vm := &kubevirtv1.VirtualMachine{
+		ObjectMeta: metav1.ObjectMeta{Name: "db-primary", Namespace: "default", Labels: map[string]string{"app": "db"}},
+	}
+	w.OnUpdate(update(vmKey, vm))
+
+	vmiKey := model.ResourceKey{Kind: internalapi.KindKubeVirtVMI, Namespace: "default", Name: "db-primary"}
+	vmi := &kubevirtv1.VirtualMachineInstance{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "db-primary", Namespace: "default",
+			OwnerReferences: []metav1.OwnerReference{{Kind: "VirtualMachine", Name: "db-primary"}},
+		},
+	}
+	w.OnUpdate(update(vmiKey, vmi))
+
+	w.OnUpdate(update(endpointKey, wep))
+
+	pr.Flush()
+
+	if len(recorder.updates) != 1 {
+		t.Fatalf("expected 1 update, got %d", len(recorder.updates))
+	}
+	cds := recorder.updates[0].ComputedData
+	if len(cds) != 1 {
+		t.Fatalf("expected 1 computed data entry, got %d", len(cds))
+	}
+	cd, ok := cds[0].(*kubeVirtVMComputedData)
+	if !ok {
+		t.Fatalf("expected *kubeVirtVMComputedData, got %T", cds[0])
+	}
+	if cd.vmName != "db-primary" || cd.vmLabels["app"] != "db" {
+		t.Errorf("unexpected computed data: %+v", cd)
+	}
+}
+
+// TestKubeVirtVMWatcher_EndpointDeleteClearsComputedData mirrors
+// TestPolicyResolver_EndpointDeleteClearsComputedData, for the KubeVirt-sourced computed data
+// this chunk adds rather than a hand-built one.
+func TestKubeVirtVMWatcher_EndpointDeleteClearsComputedData(t *testing.T) {
+	pr, _ := createPolicyResolver()
+	pr.OnDatamodelStatus(api.InSync)
+	registry := NewComputedDataRegistry(pr)
+	w := NewKubeVirtVMWatcher()
+	registry.Register(w)
+
+	endpointKey := model.WorkloadEndpointKey{Hostname: "host1", WorkloadID: "default/virt-launcher-db-primary-abcde"}
+	wep := &model.WorkloadEndpoint{Name: "we1", Labels: map[string]string{domainLabel: "db-primary"}}
+	pr.endpoints[endpointKey] = wep
+
+	vmKey := model.ResourceKey{Kind: internalapi.KindKubeVirtVM, Namespace: "default", Name: "db-primary"}
+	vm := &kubevirtv1.VirtualMachine{ObjectMeta: metav1.ObjectMeta{Name: "db-primary", Namespace: "default"}}
+	w.OnUpdate(update(vmKey, vm))
+
+	vmiKey := model.ResourceKey{Kind: internalapi.KindKubeVirtVMI, Namespace: "default", Name: "db-primary"}
+	vmi := &kubevirtv1.VirtualMachineInstance{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "db-primary", Namespace: "default",
+			OwnerReferences: []metav1.OwnerReference{{Kind: "VirtualMachine", Name: "db-primary"}},
+		},
+	}
+	w.OnUpdate(update(vmiKey, vmi))
+	w.OnUpdate(update(endpointKey, wep))
+
+	if len(w.endpointsByVMI[vmiKey]) != 1 {
+		t.Fatalf("expected endpoint to be tracked against the VMI")
+	}
+
+	// Endpoint deletion.
+	w.OnUpdate(update(endpointKey, nil))
+	if len(w.endpointsByVMI[vmiKey]) != 0 {
+		t.Errorf("expected endpoint to be untracked after deletion")
+	}
+
+	// VM deletion should fire a nil update for any remaining endpoints (none left here, but the
+	// index should still be cleaned up without panicking).
+	w.OnUpdate(update(vmKey, nil))
+}
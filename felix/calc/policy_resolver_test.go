@@ -344,13 +344,14 @@ func TestPolicyResolver_ComputedDataMultipleKinds(t *testing.T) {
 	if len(recorder.updates[0].ComputedData) != 2 {
 		t.Fatalf("expected 2 computed data entries, got %d", len(recorder.updates[0].ComputedData))
 	}
-	// Since map iteration order is non-deterministic, check both are present.
-	found := map[string]bool{}
-	for _, cd := range recorder.updates[0].ComputedData {
-		found[cd.(*testComputedData).Value] = true
-	}
-	if !found["a"] || !found["b"] {
-		t.Errorf("expected both computed data kinds, got %v", found)
+	// Neither kind went through a ComputedDataRegistry, so PolicyResolver falls back to
+	// alphabetical-by-Kind ordering ("kindA" < "kindB") rather than a non-deterministic map
+	// iteration order.
+	if recorder.updates[0].ComputedData[0].(*testComputedData) != cdA {
+		t.Errorf("expected ComputedData[0] to be cdA, got %v", recorder.updates[0].ComputedData[0])
+	}
+	if recorder.updates[0].ComputedData[1].(*testComputedData) != cdB {
+		t.Errorf("expected ComputedData[1] to be cdB, got %v", recorder.updates[0].ComputedData[1])
 	}
 }
 
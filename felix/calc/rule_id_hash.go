@@ -0,0 +1,85 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package calc
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+)
+
+// RuleIDHashFields names the RuleID fields CanonicalID mixes into its content hash, in the order
+// FelixConfiguration's RuleIDHashFields lists them. Unrecognized names are ignored by
+// hashFieldValue so a rolling upgrade between Felix versions that adds a new hashable field
+// doesn't fail closed on an older cluster's config.
+type RuleIDHashFields []string
+
+// DefaultRuleIDHashFields is used when FelixConfiguration's RuleIDHashFields is unset.
+var DefaultRuleIDHashFields = RuleIDHashFields{"Tier", "Name", "Namespace", "Action", "Direction", "Index"}
+
+// ParseRuleIDHashFields parses FelixConfiguration's comma-separated RuleIDHashFields value,
+// falling back to DefaultRuleIDHashFields for an empty string.
+func ParseRuleIDHashFields(raw string) RuleIDHashFields {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return DefaultRuleIDHashFields
+	}
+	var fields RuleIDHashFields
+	for _, f := range strings.Split(raw, ",") {
+		if f = strings.TrimSpace(f); f != "" {
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
+
+// CanonicalID returns a stable content hash of r over fields: two RuleIDs that agree on every
+// named field hash identically, and differ otherwise. This is what lets the collector dedupe
+// flow logs for "the same rule" across a Felix restart or a policy re-sync that only renumbers
+// Index, by choosing a fields set that omits whichever of its own fields churns across those
+// events, while still distinguishing rules that genuinely changed.
+func (r *RuleID) CanonicalID(fields RuleIDHashFields) string {
+	h := sha256.New()
+	for _, f := range fields {
+		h.Write([]byte(f))
+		h.Write([]byte{0})
+		h.Write([]byte(r.hashFieldValue(f)))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// hashFieldValue returns the string form CanonicalID mixes into the hash for one named field,
+// or "" for a name it doesn't recognize (selectors and other rule metadata land here once
+// RuleProvenance threads that detail through).
+func (r *RuleID) hashFieldValue(field string) string {
+	switch field {
+	case "Tier":
+		return r.Tier
+	case "Name":
+		return r.Name
+	case "Namespace":
+		return r.Namespace
+	case "Action":
+		return string(r.Action)
+	case "Direction":
+		return string(r.Direction)
+	case "Index":
+		return strconv.Itoa(r.Index)
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package calc
+
+import "sort"
+
+// LabelPair is a single key=value label match, recorded instead of just a map entry so the order
+// labels were checked in survives into a flow log or calicoctl's "why did this match" output.
+type LabelPair struct {
+	Key   string
+	Value string
+}
+
+// LabelArrayList is an ordered list of LabelPairs, the provenance equivalent of a label map.
+type LabelArrayList []LabelPair
+
+// RuleProvenance records why a particular RuleID matched a given endpoint pair: the selector
+// expression that was evaluated, the subset of the endpoint's labels that satisfied it, and, for
+// a rule inherited from another resource, the resource it was derived from (the annotation, or
+// the GlobalNetworkPolicy/Kubernetes NetworkPolicy a Calico policy's rule was generated from).
+// Collector attaches one of these per entry in Data's IngressPendingRuleIDs/EgressPendingRuleIDs,
+// and flow logs/calicoctl use it to answer "why did this policy hit this workload?".
+type RuleProvenance struct {
+	// Selector is the raw selector expression evaluated against the endpoint.
+	Selector string
+	// MatchedLabels is the subset of the endpoint's labels that satisfied Selector.
+	MatchedLabels LabelArrayList
+	// DerivedFromKind/DerivedFromName identify the resource a rule was adopted from -- e.g.
+	// "NetworkPolicy"/"my-knp" for a rule Calico generated from a Kubernetes NetworkPolicy --
+	// and are both empty for a rule authored directly as a Calico policy.
+	DerivedFromKind string
+	DerivedFromName string
+}
+
+// NewRuleProvenance builds a RuleProvenance for a rule matched via selector against the subset of
+// endpoint labels recorded in matched, with no derivation (a directly-authored Calico policy
+// rule). Use WithDerivedFrom to record an adopted rule's source resource.
+func NewRuleProvenance(selector string, matched map[string]string) RuleProvenance {
+	return RuleProvenance{Selector: selector, MatchedLabels: newLabelArrayList(matched)}
+}
+
+// WithDerivedFrom returns a copy of p recording that its rule was adopted from the named
+// resource.
+func (p RuleProvenance) WithDerivedFrom(kind, name string) RuleProvenance {
+	p.DerivedFromKind = kind
+	p.DerivedFromName = name
+	return p
+}
+
+// newLabelArrayList turns a label map into a LabelArrayList sorted by key, so two calls over the
+// same labels always produce the same order regardless of Go's randomized map iteration.
+func newLabelArrayList(labels map[string]string) LabelArrayList {
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	list := make(LabelArrayList, 0, len(keys))
+	for _, k := range keys {
+		list = append(list, LabelPair{Key: k, Value: labels[k]})
+	}
+	return list
+}
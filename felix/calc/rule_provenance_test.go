@@ -0,0 +1,50 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package calc
+
+import "testing"
+
+func TestNewRuleProvenance_OrdersMatchedLabelsByKey(t *testing.T) {
+	p := NewRuleProvenance("role == 'db'", map[string]string{
+		"role": "db",
+		"env":  "prod",
+	})
+
+	if p.Selector != "role == 'db'" {
+		t.Errorf("expected selector to be preserved, got %q", p.Selector)
+	}
+	want := LabelArrayList{{Key: "env", Value: "prod"}, {Key: "role", Value: "db"}}
+	if len(p.MatchedLabels) != len(want) {
+		t.Fatalf("expected %d matched labels, got %d: %v", len(want), len(p.MatchedLabels), p.MatchedLabels)
+	}
+	for i := range want {
+		if p.MatchedLabels[i] != want[i] {
+			t.Errorf("matched label %d: expected %+v, got %+v", i, want[i], p.MatchedLabels[i])
+		}
+	}
+}
+
+func TestRuleProvenance_WithDerivedFrom_DoesNotMutateReceiver(t *testing.T) {
+	base := NewRuleProvenance("all()", nil)
+
+	derived := base.WithDerivedFrom("NetworkPolicy", "my-knp")
+
+	if base.DerivedFromKind != "" || base.DerivedFromName != "" {
+		t.Errorf("expected base provenance to stay unchanged, got %+v", base)
+	}
+	if derived.DerivedFromKind != "NetworkPolicy" || derived.DerivedFromName != "my-knp" {
+		t.Errorf("expected derived provenance to carry the source resource, got %+v", derived)
+	}
+}
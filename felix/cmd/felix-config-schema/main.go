@@ -0,0 +1,75 @@
+// Copyright (c) 2025 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// felix-config-schema writes machine-readable descriptions of the Felix config surface, so that
+// drift between the Go Config struct and the published FelixConfiguration CRD/OpenAPI schema can
+// be caught in CI rather than discovered in the field.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/projectcalico/calico/felix/config"
+	"github.com/projectcalico/calico/felix/config/schema"
+)
+
+func main() {
+	out := flag.String("out", "-", "File to write the schema to, or '-' for stdout")
+	format := flag.String("format", "json", "Output format: json, crd-fragment or versioned")
+	flag.Parse()
+
+	var w *os.File
+	if *out == "-" {
+		w = os.Stdout
+	} else {
+		f, err := os.Create(*out)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "failed to create %s: %v\n", *out, err)
+			os.Exit(1)
+		}
+		defer f.Close()
+		w = f
+	}
+
+	// All three formats below render config.BuildSchemaDocument's output, just reshaped
+	// differently: "versioned" is the full document as-is (schema version, source priority,
+	// enum/deprecation metadata included), "json" and "crd-fragment" go through package schema's
+	// narrower FieldSchema view for callers that don't want those extra fields.
+	var err error
+	switch *format {
+	case "versioned":
+		// Dumps config.SchemaDocument, which additionally carries a schema version and the
+		// config source-priority order, so CI can diff it to catch accidental breaking changes
+		// to the config surface.
+		err = config.DumpSchema(w)
+	case "json":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		err = enc.Encode(schema.Build())
+	case "crd-fragment":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		err = enc.Encode(schema.CRDValidationFragment())
+	default:
+		fmt.Fprintf(os.Stderr, "unknown format %q\n", *format)
+		os.Exit(1)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to write schema: %v\n", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,583 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !windows
+// +build !windows
+
+package collector
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/projectcalico/calico/felix/calc"
+	"github.com/projectcalico/calico/felix/collector/metrics"
+	clttypes "github.com/projectcalico/calico/felix/collector/types"
+	"github.com/projectcalico/calico/felix/nfnetlink"
+	"github.com/projectcalico/calico/felix/rules"
+)
+
+// AuditRecord is a single structured allow/deny decision, as reported by AuditLogger. Unlike the
+// flow log exporter's aggregated per-tuple stats, each AuditRecord corresponds to one NFLOG
+// packet-filtering decision (or, in aggregated mode, one burst of identical decisions for the
+// same 5-tuple within the aggregation window).
+type AuditRecord struct {
+	Time      time.Time `json:"time"`
+	Direction string    `json:"direction"`
+	Action    string    `json:"action"`
+	Tier      string    `json:"tier"`
+	Policy    string    `json:"policy"`
+	Namespace string    `json:"namespace,omitempty"`
+	RuleIndex string    `json:"ruleIndex"`
+	Label     string    `json:"label,omitempty"`
+
+	SrcIP    string `json:"srcIP"`
+	DstIP    string `json:"dstIP"`
+	SrcPort  int    `json:"srcPort,omitempty"`
+	DstPort  int    `json:"dstPort,omitempty"`
+	Protocol int    `json:"protocol"`
+
+	SrcEndpoint   string `json:"srcEndpoint,omitempty"`
+	DstEndpoint   string `json:"dstEndpoint,omitempty"`
+	SrcService    string `json:"srcService,omitempty"`
+	DstService    string `json:"dstService,omitempty"`
+	SrcNetworkSet string `json:"srcNetworkSet,omitempty"`
+	DstNetworkSet string `json:"dstNetworkSet,omitempty"`
+
+	Packets int `json:"packets"`
+	Bytes   int `json:"bytes"`
+
+	// AggregatedCount is the number of identical (5-tuple, rule) decisions folded into this
+	// record by the aggregation window; zero when aggregation is disabled or this is the only
+	// occurrence seen.
+	AggregatedCount int `json:"aggregatedCount,omitempty"`
+
+	// TCP quality metrics for this record's 5-tuple, populated when a TCPInfoReader is
+	// attached via SetTCPInfoReader and has observed the local socket; omitted entirely when
+	// no reader is attached or the socket hasn't been polled yet.
+	TCPSRTTMicros  int64 `json:"tcpSRTTMicros,omitempty"`
+	TCPRetransmits int   `json:"tcpRetransmits,omitempty"`
+	TCPMinWindow   int   `json:"tcpMinWindow,omitempty"`
+	TCPMaxWindow   int   `json:"tcpMaxWindow,omitempty"`
+
+	// DNAT fields, populated when a ConntrackBackend is attached via SetConntrackBackend and
+	// has a matching conntrack entry for this record's 5-tuple. PreDNATDstIP/PreDNATDstPort
+	// are the destination the client originally dialed, before translation to DstIP/DstPort.
+	IsDNAT         bool   `json:"isDNAT,omitempty"`
+	PreDNATDstIP   string `json:"preDNATDstIP,omitempty"`
+	PreDNATDstPort int    `json:"preDNATDstPort,omitempty"`
+}
+
+// AuditSink is where AuditLogger delivers records. The file and syslog backends in
+// audit_sink.go are the two built-in implementations.
+type AuditSink interface {
+	WriteRecord(AuditRecord) error
+	Close() error
+}
+
+// AuditLogger consumes NflogPacketAggregate batches from a NFLogReader's IngressC/EgressC
+// channels, enriches each packet-filtering decision with rule, endpoint and service/netset
+// identity via the LookupsCache, and writes the result to a AuditSink. It applies per-rule
+// sampling and rate-limiting before a record ever reaches the sink, and can optionally fold
+// repeated identical 5-tuple decisions within a configurable window into one record with an
+// AggregatedCount, so a noisy Deny rule can't flood the sink.
+type AuditLogger struct {
+	lookups *calc.LookupsCache
+	sink    AuditSink
+
+	sampleRate     float64
+	rateLimiter    *rateLimiter
+	aggregationTTL time.Duration
+
+	mu      sync.Mutex
+	aggKeys map[auditAggKey]*auditAggEntry
+	wg      sync.WaitGroup
+	stopC   chan struct{}
+
+	tcpMu    sync.Mutex
+	tcpStats map[fiveTupleKey]clttypes.TCPInfo
+
+	// policyCounters is updated for every NFLOG decision AuditLogger sees, independent of
+	// sampleRate/rateLimiter: it's Felix's always-on policy hit-rate signal, and shouldn't
+	// drop counts just because the (optional) audit log is sampling or rate-limited.
+	policyCounters *metrics.PolicyCounters
+
+	ctMu   sync.Mutex
+	ctInfo map[fiveTupleKey]clttypes.ConntrackInfo
+
+	ipfixMu sync.Mutex
+	ipfix   *IPFIXExporter
+
+	connStatsMu    sync.Mutex
+	connStatsSink  ConnStatsSink
+	connStatsState map[fiveTupleKey]connStatsState
+}
+
+// NewAuditLogger builds an AuditLogger that writes enriched records to sink. sampleRate is the
+// fraction (0.0-1.0) of decisions that are considered for logging at all; rateLimitPerSecond
+// bounds the steady-state rate of records actually written, regardless of how many decisions
+// pass sampling; aggregationWindow, if non-zero, folds repeated identical 5-tuple decisions
+// within that window into a single record.
+func NewAuditLogger(lookups *calc.LookupsCache, sink AuditSink, sampleRate float64, rateLimitPerSecond int, aggregationWindow time.Duration) *AuditLogger {
+	return &AuditLogger{
+		lookups:        lookups,
+		sink:           sink,
+		sampleRate:     sampleRate,
+		rateLimiter:    newRateLimiter(rateLimitPerSecond),
+		aggregationTTL: aggregationWindow,
+		aggKeys:        map[auditAggKey]*auditAggEntry{},
+		stopC:          make(chan struct{}),
+		policyCounters: metrics.NewPolicyCounters(0),
+	}
+}
+
+// ConsumeFrom starts goroutines draining reader's IngressC and EgressC channels, the same
+// channels the collector's own NFLOG processing loop drains, so the audit log sees every
+// allow/deny decision Felix's main pipeline does.
+func (a *AuditLogger) ConsumeFrom(reader *NFLogReader) {
+	a.wg.Add(2)
+	go a.loop(rules.RuleDirIngress, reader.IngressC)
+	go a.loop(rules.RuleDirEgress, reader.EgressC)
+	if a.aggregationTTL > 0 {
+		a.wg.Add(1)
+		go a.flushExpiredAggregates()
+	}
+}
+
+// SetTCPInfoReader attaches reader as the source of per-socket TCP quality metrics (smoothed
+// RTT, retransmits, send window) that subsequent records are enriched with, keyed by 5-tuple. It
+// starts reader and begins draining its TCPInfoChan in the background; call it before
+// ConsumeFrom so no early records race the first poll.
+func (a *AuditLogger) SetTCPInfoReader(reader TCPInfoReader) {
+	a.tcpMu.Lock()
+	if a.tcpStats == nil {
+		a.tcpStats = map[fiveTupleKey]clttypes.TCPInfo{}
+	}
+	a.tcpMu.Unlock()
+
+	reader.Start()
+	a.wg.Add(1)
+	go func() {
+		defer a.wg.Done()
+		for {
+			select {
+			case <-a.stopC:
+				reader.Stop()
+				return
+			case info, ok := <-reader.TCPInfoChan():
+				if !ok {
+					return
+				}
+				a.tcpMu.Lock()
+				a.tcpStats[fiveTupleKeyFor(info.SrcIP, info.DstIP, info.SrcPort, info.DstPort, info.Protocol)] = info
+				a.tcpMu.Unlock()
+			}
+		}
+	}()
+}
+
+// SetConntrackBackend attaches backend as the source of DNAT information that subsequent
+// records are enriched with, keyed by 5-tuple. It subscribes to backend in the background; call
+// it before ConsumeFrom so no early records race the first update.
+func (a *AuditLogger) SetConntrackBackend(backend ConntrackBackend) {
+	a.ctMu.Lock()
+	if a.ctInfo == nil {
+		a.ctInfo = map[fiveTupleKey]clttypes.ConntrackInfo{}
+	}
+	a.ctMu.Unlock()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	updates := backend.Subscribe(ctx)
+
+	a.wg.Add(1)
+	go func() {
+		defer a.wg.Done()
+		defer cancel()
+		for {
+			select {
+			case <-a.stopC:
+				return
+			case info, ok := <-updates:
+				if !ok {
+					return
+				}
+				key := fiveTupleKeyFor(info.SrcIP, info.DstIP, info.SrcPort, info.DstPort, info.Protocol)
+				a.ctMu.Lock()
+				a.ctInfo[key] = info
+				a.ctMu.Unlock()
+
+				natOutgoingPort := 0
+				if info.IsSNAT {
+					natOutgoingPort = info.PostSNATPort
+				}
+				a.publishConnStats(key, info.SrcIP, info.DstIP, info.SrcPort, info.DstPort, info.Protocol, "", "", natOutgoingPort, info.OrigCounters, info.ReplyCounters)
+			}
+		}
+	}()
+}
+
+// SetIPFIXExporter attaches exp as an additional destination for every record emit writes to
+// the sink, so the collector fans flow records out to both the configured AuditSink and IPFIX
+// collectors at once.
+func (a *AuditLogger) SetIPFIXExporter(exp *IPFIXExporter) {
+	a.ipfixMu.Lock()
+	defer a.ipfixMu.Unlock()
+	a.ipfix = exp
+}
+
+// fiveTupleKey identifies a socket by its 5-tuple for TCP stats lookups; stringifying the IPs
+// keeps the key comparable without pulling net.IP's byte-slice representation into a map key.
+type fiveTupleKey struct {
+	srcIP    string
+	dstIP    string
+	srcPort  int
+	dstPort  int
+	protocol int
+}
+
+func fiveTupleKeyFor(srcIP, dstIP net.IP, srcPort, dstPort, protocol int) fiveTupleKey {
+	return fiveTupleKey{srcIP: srcIP.String(), dstIP: dstIP.String(), srcPort: srcPort, dstPort: dstPort, protocol: protocol}
+}
+
+// Stop shuts down the consumer goroutines and closes the sink.
+func (a *AuditLogger) Stop() error {
+	close(a.stopC)
+	a.wg.Wait()
+	return a.sink.Close()
+}
+
+func (a *AuditLogger) loop(dir rules.RuleDir, in <-chan map[nfnetlink.NflogPacketTuple]*nfnetlink.NflogPacketAggregate) {
+	defer a.wg.Done()
+	for {
+		select {
+		case <-a.stopC:
+			return
+		case batch, ok := <-in:
+			if !ok {
+				return
+			}
+			for _, agg := range batch {
+				a.processAggregate(dir, agg)
+			}
+		}
+	}
+}
+
+func (a *AuditLogger) processAggregate(dir rules.RuleDir, agg *nfnetlink.NflogPacketAggregate) {
+	for _, prefix := range agg.Prefixes {
+		ruleID := a.lookups.GetRuleIDFromNFLOGPrefix(prefix.Prefix)
+		if ruleID == nil {
+			log.WithField("prefix", prefix.Prefix).Debug("AuditLogger: no rule matched NFLOG prefix; dropping")
+			continue
+		}
+		a.policyCounters.Record(ruleID.Tier, ruleID.Name, ruleID.Action.String(), dir.String(), ruleID.Namespace, prefix.Packets, prefix.Bytes)
+
+		if !a.shouldSample(ruleID) {
+			continue
+		}
+		record := a.buildRecord(dir, agg, prefix, ruleID)
+
+		if a.aggregationTTL > 0 {
+			if a.foldIntoAggregate(record) {
+				continue
+			}
+		}
+		a.emit(record)
+	}
+}
+
+func (a *AuditLogger) shouldSample(ruleID *calc.RuleID) bool {
+	if a.sampleRate >= 1.0 {
+		return true
+	}
+	if a.sampleRate <= 0 {
+		return false
+	}
+	return sampleHash(ruleID, time.Now().UnixNano())%1000 < int(a.sampleRate*1000)
+}
+
+func (a *AuditLogger) buildRecord(dir rules.RuleDir, agg *nfnetlink.NflogPacketAggregate, prefix nfnetlink.NflogPrefix, ruleID *calc.RuleID) AuditRecord {
+	record := AuditRecord{
+		Time:      time.Now(),
+		Direction: dir.String(),
+		Action:    ruleID.Action.String(),
+		Tier:      ruleID.Tier,
+		Policy:    ruleID.Name,
+		Namespace: ruleID.Namespace,
+		RuleIndex: ruleID.IndexStr,
+		Label:     nflogLabelFromPrefix(prefix.Prefix),
+		Protocol:  int(agg.Tuple.Proto),
+		SrcPort:   agg.Tuple.L4Src.Port,
+		DstPort:   agg.Tuple.L4Dst.Port,
+		Packets:   prefix.Packets,
+		Bytes:     prefix.Bytes,
+	}
+	record.SrcIP = ipStr16(agg.Tuple.Src)
+	record.DstIP = ipStr16(agg.Tuple.Dst)
+
+	if srcEp, ok := a.lookups.GetEndpoint(agg.Tuple.Src); ok {
+		record.SrcEndpoint = endpointName(srcEp)
+	}
+	if dstEp, ok := a.lookups.GetEndpoint(agg.Tuple.Dst); ok {
+		record.DstEndpoint = endpointName(dstEp)
+	}
+	a.applyTCPStats(&record, agg)
+	a.applyConntrackInfo(&record, agg)
+	return record
+}
+
+// applyConntrackInfo fills in record's DNAT fields from the most recent ConntrackInfo seen for
+// its 5-tuple, if any, and publishes a ConnStatsSnapshot for the flow if a ConnStatsSink is
+// attached; it's a no-op when SetConntrackBackend was never called or the entry hasn't been
+// observed yet.
+func (a *AuditLogger) applyConntrackInfo(record *AuditRecord, agg *nfnetlink.NflogPacketAggregate) {
+	srcIP := net.IP(agg.Tuple.Src[:])
+	dstIP := net.IP(agg.Tuple.Dst[:])
+	key := fiveTupleKeyFor(srcIP, dstIP, agg.Tuple.L4Src.Port, agg.Tuple.L4Dst.Port, int(agg.Tuple.Proto))
+
+	a.ctMu.Lock()
+	var info clttypes.ConntrackInfo
+	var ok bool
+	if a.ctInfo != nil {
+		info, ok = a.ctInfo[key]
+	}
+	a.ctMu.Unlock()
+
+	if ok {
+		natOutgoingPort := 0
+		if info.IsSNAT {
+			natOutgoingPort = info.PostSNATPort
+		}
+		a.publishConnStats(key, srcIP, dstIP, agg.Tuple.L4Src.Port, agg.Tuple.L4Dst.Port, int(agg.Tuple.Proto),
+			record.SrcEndpoint, record.DstEndpoint, natOutgoingPort, info.OrigCounters, info.ReplyCounters)
+	}
+
+	if !ok || !info.IsDNAT {
+		return
+	}
+	record.IsDNAT = true
+	record.PreDNATDstIP = info.PreDNATAddr.String()
+	record.PreDNATDstPort = info.PreDNATPort
+}
+
+// applyTCPStats fills in record's TCP quality fields from the most recent TCPInfo seen for its
+// 5-tuple, if any; it's a no-op when SetTCPInfoReader was never called or the socket hasn't been
+// polled yet.
+func (a *AuditLogger) applyTCPStats(record *AuditRecord, agg *nfnetlink.NflogPacketAggregate) {
+	a.tcpMu.Lock()
+	defer a.tcpMu.Unlock()
+	if a.tcpStats == nil {
+		return
+	}
+	key := fiveTupleKeyFor(net.IP(agg.Tuple.Src[:]), net.IP(agg.Tuple.Dst[:]), agg.Tuple.L4Src.Port, agg.Tuple.L4Dst.Port, int(agg.Tuple.Proto))
+	info, ok := a.tcpStats[key]
+	if !ok {
+		return
+	}
+	record.TCPSRTTMicros = info.SRTT.Microseconds()
+	record.TCPRetransmits = info.Retransmits
+	record.TCPMinWindow = int(info.MinWindow)
+	record.TCPMaxWindow = int(info.MaxWindow)
+}
+
+// emit hands record to the sink, applying the overall rate limit as the last gate before
+// anything touches disk or the network.
+func (a *AuditLogger) emit(record AuditRecord) {
+	if !a.rateLimiter.Allow() {
+		return
+	}
+	if a.sink != nil {
+		if err := a.sink.WriteRecord(record); err != nil {
+			log.WithError(err).Warn("AuditLogger: failed to write audit record")
+		}
+	}
+
+	a.ipfixMu.Lock()
+	exp := a.ipfix
+	a.ipfixMu.Unlock()
+	if exp != nil {
+		if err := exp.Export(record); err != nil {
+			log.WithError(err).Warn("AuditLogger: failed to export IPFIX record")
+		}
+	}
+}
+
+// auditAggKey identifies a 5-tuple + rule + direction for the "aggregate identical decisions"
+// mode; two decisions with the same key within aggregationTTL become one record.
+type auditAggKey struct {
+	dir       string
+	tier      string
+	policy    string
+	ruleIndex string
+	srcIP     string
+	dstIP     string
+	srcPort   int
+	dstPort   int
+	protocol  int
+}
+
+type auditAggEntry struct {
+	record  AuditRecord
+	count   int
+	started time.Time
+}
+
+func auditAggKeyFor(record AuditRecord) auditAggKey {
+	return auditAggKey{
+		dir:       record.Direction,
+		tier:      record.Tier,
+		policy:    record.Policy,
+		ruleIndex: record.RuleIndex,
+		srcIP:     record.SrcIP,
+		dstIP:     record.DstIP,
+		srcPort:   record.SrcPort,
+		dstPort:   record.DstPort,
+		protocol:  record.Protocol,
+	}
+}
+
+// foldIntoAggregate returns true if record was folded into an existing aggregate (and so should
+// not be emitted on its own); the aggregate itself is flushed once aggregationTTL elapses, by
+// flushExpiredAggregates.
+func (a *AuditLogger) foldIntoAggregate(record AuditRecord) bool {
+	key := auditAggKeyFor(record)
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	entry, exists := a.aggKeys[key]
+	if !exists {
+		a.aggKeys[key] = &auditAggEntry{record: record, count: 1, started: time.Now()}
+		return false
+	}
+	entry.count++
+	entry.record.Packets += record.Packets
+	entry.record.Bytes += record.Bytes
+	return true
+}
+
+func (a *AuditLogger) flushExpiredAggregates() {
+	defer a.wg.Done()
+	ticker := time.NewTicker(a.aggregationTTL)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-a.stopC:
+			return
+		case <-ticker.C:
+			a.flushExpiredAggregatesOnce()
+		}
+	}
+}
+
+func (a *AuditLogger) flushExpiredAggregatesOnce() {
+	now := time.Now()
+	var toEmit []AuditRecord
+
+	a.mu.Lock()
+	for key, entry := range a.aggKeys {
+		if now.Sub(entry.started) < a.aggregationTTL {
+			continue
+		}
+		if entry.count <= 1 {
+			// The only occurrence was already emitted on its own by processAggregate.
+			delete(a.aggKeys, key)
+			continue
+		}
+		record := entry.record
+		record.AggregatedCount = entry.count
+		toEmit = append(toEmit, record)
+		delete(a.aggKeys, key)
+	}
+	a.mu.Unlock()
+
+	for _, record := range toEmit {
+		a.emit(record)
+	}
+}
+
+func nflogLabelFromPrefix(prefix [64]byte) string {
+	for i, b := range prefix {
+		if b == 0 {
+			return string(prefix[:i])
+		}
+	}
+	return string(prefix[:])
+}
+
+func endpointName(ep calc.EndpointData) string {
+	return fmt.Sprintf("%v", ep.Key())
+}
+
+func ipStr16(ip [16]byte) string {
+	return net.IP(ip[:]).String()
+}
+
+// sampleHash derives a stable-enough pseudo-random bucket from ruleID and a salt, without
+// pulling in a CSPRNG for what is purely a load-shedding decision.
+func sampleHash(ruleID *calc.RuleID, salt int64) int {
+	h := int64(0)
+	for _, b := range []byte(ruleID.Tier + ruleID.Name + ruleID.IndexStr) {
+		h = h*31 + int64(b)
+	}
+	h ^= salt
+	if h < 0 {
+		h = -h
+	}
+	return int(h % 1000)
+}
+
+// rateLimiter is a simple token-bucket limiter refilled once per second, used to cap the
+// steady-state rate of audit records reaching the sink regardless of how bursty the NFLOG
+// traffic feeding AuditLogger is.
+type rateLimiter struct {
+	mu         sync.Mutex
+	perSecond  int
+	tokens     int
+	lastRefill time.Time
+}
+
+func newRateLimiter(perSecond int) *rateLimiter {
+	return &rateLimiter{perSecond: perSecond, tokens: perSecond, lastRefill: time.Now()}
+}
+
+func (r *rateLimiter) Allow() bool {
+	if r.perSecond <= 0 {
+		return true
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if elapsed := time.Since(r.lastRefill); elapsed >= time.Second {
+		r.tokens = r.perSecond
+		r.lastRefill = time.Now()
+	}
+	if r.tokens <= 0 {
+		return false
+	}
+	r.tokens--
+	return true
+}
+
+// marshalRecord is a small indirection so tests (and future sinks) don't need to know
+// AuditRecord's exact JSON shape to assert a record was written.
+func marshalRecord(r AuditRecord) ([]byte, error) {
+	return json.Marshal(r)
+}
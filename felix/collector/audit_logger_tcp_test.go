@@ -0,0 +1,120 @@
+//go:build !windows
+// +build !windows
+
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"net"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/projectcalico/calico/felix/calc"
+	clttypes "github.com/projectcalico/calico/felix/collector/types"
+	"github.com/projectcalico/calico/felix/nfnetlink"
+	"github.com/projectcalico/calico/felix/rules"
+)
+
+// fakeTCPInfoReader is a TCPInfoReader that publishes whatever records are pushed onto it via
+// Publish, so tests don't need a real socket to poll.
+type fakeTCPInfoReader struct {
+	out chan clttypes.TCPInfo
+}
+
+func newFakeTCPInfoReader() *fakeTCPInfoReader {
+	return &fakeTCPInfoReader{out: make(chan clttypes.TCPInfo, 10)}
+}
+
+func (f *fakeTCPInfoReader) Start()                              {}
+func (f *fakeTCPInfoReader) Stop()                               { close(f.out) }
+func (f *fakeTCPInfoReader) TCPInfoChan() <-chan clttypes.TCPInfo { return f.out }
+func (f *fakeTCPInfoReader) Publish(info clttypes.TCPInfo)        { f.out <- info }
+
+func ipTo16Byte(s string) (out [16]byte) {
+	copy(out[:], net.ParseIP(s).To16())
+	return out
+}
+
+var _ = Describe("AuditLogger TCP stats", func() {
+	const (
+		srcIPStr = "10.0.0.1"
+		dstIPStr = "20.0.0.1"
+		srcPort  = 20000
+		dstPort  = 80
+		proto    = 6
+	)
+
+	var (
+		a      *AuditLogger
+		reader *fakeTCPInfoReader
+		agg    *nfnetlink.NflogPacketAggregate
+		ruleID *calc.RuleID
+	)
+
+	BeforeEach(func() {
+		a = NewAuditLogger(calc.NewLookupsCache(), nil, 1.0, 0, 0)
+		reader = newFakeTCPInfoReader()
+		a.SetTCPInfoReader(reader)
+
+		agg = &nfnetlink.NflogPacketAggregate{
+			Tuple: nfnetlink.NflogPacketTuple{
+				Src:   ipTo16Byte(srcIPStr),
+				Dst:   ipTo16Byte(dstIPStr),
+				Proto: proto,
+				L4Src: nfnetlink.NflogL4Info{Port: srcPort},
+				L4Dst: nfnetlink.NflogL4Info{Port: dstPort},
+			},
+			Prefixes: []nfnetlink.NflogPrefix{
+				{Bytes: 100, Packets: 1},
+			},
+		}
+		ruleID = &calc.RuleID{
+			PolicyID: calc.PolicyID{Tier: "default", Name: "policy1"},
+			IndexStr: "0",
+			Action:   rules.RuleActionAllow,
+		}
+	})
+
+	It("enriches a record with the TCP stats observed for its 5-tuple", func() {
+		reader.Publish(clttypes.TCPInfo{
+			SrcIP:       net.ParseIP(srcIPStr),
+			DstIP:       net.ParseIP(dstIPStr),
+			SrcPort:     srcPort,
+			DstPort:     dstPort,
+			Protocol:    proto,
+			SRTT:        25 * time.Millisecond,
+			Retransmits: 3,
+			MinWindow:   4096,
+			MaxWindow:   65535,
+		})
+
+		Eventually(func() bool {
+			a.tcpMu.Lock()
+			defer a.tcpMu.Unlock()
+			_, ok := a.tcpStats[fiveTupleKeyFor(net.ParseIP(srcIPStr), net.ParseIP(dstIPStr), srcPort, dstPort, proto)]
+			return ok
+		}, "500ms", "10ms").Should(BeTrue())
+
+		record := a.buildRecord(rules.RuleDirIngress, agg, agg.Prefixes[0], ruleID)
+
+		Expect(record.TCPRetransmits).To(Equal(3))
+		Expect(record.TCPMinWindow).To(Equal(4096))
+		Expect(record.TCPMaxWindow).To(Equal(65535))
+		Expect(record.TCPSRTTMicros).To(Equal(int64(25000)))
+	})
+})
@@ -0,0 +1,105 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !windows
+// +build !windows
+
+package collector
+
+import (
+	"log/syslog"
+	"sync"
+
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+// FileAuditSink writes one JSON AuditRecord per line to a rotating log file. Rotation is
+// delegated to lumberjack, the same size/age/backup-count rotation model Felix's other
+// file-based logs use.
+type FileAuditSink struct {
+	mu     sync.Mutex
+	logger *lumberjack.Logger
+}
+
+// NewFileAuditSink opens (or creates) path for append, rotating it once it exceeds maxSizeMB,
+// keeping at most maxBackups old files for at most maxAgeDays each.
+func NewFileAuditSink(path string, maxSizeMB, maxAgeDays, maxBackups int) *FileAuditSink {
+	return &FileAuditSink{
+		logger: &lumberjack.Logger{
+			Filename:   path,
+			MaxSize:    maxSizeMB,
+			MaxAge:     maxAgeDays,
+			MaxBackups: maxBackups,
+			Compress:   true,
+		},
+	}
+}
+
+func (s *FileAuditSink) WriteRecord(record AuditRecord) error {
+	line, err := marshalRecord(record)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, err = s.logger.Write(line)
+	return err
+}
+
+func (s *FileAuditSink) Close() error {
+	return s.logger.Close()
+}
+
+// SyslogAuditSink writes each AuditRecord as a single JSON syslog message, at a severity derived
+// from the record's Action (Deny records are logged at Warning, everything else at Info).
+type SyslogAuditSink struct {
+	mu     sync.Mutex
+	writer *syslog.Writer
+}
+
+// NewSyslogAuditSink dials the syslog daemon at network/address (e.g. "udp", "localhost:514"),
+// or the local syslog socket if network and address are both empty.
+func NewSyslogAuditSink(network, address, tag string) (*SyslogAuditSink, error) {
+	var w *syslog.Writer
+	var err error
+	if network == "" && address == "" {
+		w, err = syslog.New(syslog.LOG_INFO|syslog.LOG_DAEMON, tag)
+	} else {
+		w, err = syslog.Dial(network, address, syslog.LOG_INFO|syslog.LOG_DAEMON, tag)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &SyslogAuditSink{writer: w}, nil
+}
+
+func (s *SyslogAuditSink) WriteRecord(record AuditRecord) error {
+	line, err := marshalRecord(record)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if record.Action == "Deny" {
+		return s.writer.Warning(string(line))
+	}
+	return s.writer.Info(string(line))
+}
+
+func (s *SyslogAuditSink) Close() error {
+	return s.writer.Close()
+}
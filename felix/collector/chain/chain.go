@@ -0,0 +1,228 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package chain evaluates an ordered Chain of rules against an EvalCtx describing a candidate
+// packet, matching each rule's Conditions beyond the plain L3/L4 tuple the collector's pending
+// rule trace already covers: label equality/glob on Kubernetes labels, service-account
+// principals, and numeric comparisons on packet/byte counters. It exists as its own package,
+// independent of calc and collector, so a caller need not import the collector to use it. The
+// collector's chain_eval.go can build a Chain and an EvalCtx for a tuple, but neither the
+// collector's flow-log path nor RuleTraceSimulator calls Evaluate.
+package chain
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/projectcalico/calico/felix/calc"
+)
+
+// ConditionType selects how a Condition compares EvalCtx's value for Key against Values.
+type ConditionType string
+
+const (
+	// StringEquals matches if the EvalCtx value for Key equals any entry in Values.
+	StringEquals ConditionType = "StringEquals"
+	// StringNotEquals matches if the EvalCtx value for Key equals none of Values.
+	StringNotEquals ConditionType = "StringNotEquals"
+	// StringLike matches if the EvalCtx value for Key matches any entry in Values, where '*' in
+	// a Values entry matches any run of characters (a simple glob, not a full regex).
+	StringLike ConditionType = "StringLike"
+	// NumericGreaterThan matches if the EvalCtx numeric value for Key is greater than Values[0].
+	NumericGreaterThan ConditionType = "NumericGreaterThan"
+	// NumericLessThan matches if the EvalCtx numeric value for Key is less than Values[0].
+	NumericLessThan ConditionType = "NumericLessThan"
+)
+
+// Condition is one predicate a Chain Entry requires EvalCtx to satisfy. Key names the EvalCtx
+// field to test; see EvalCtx's StringValue/NumericValue for the recognized names.
+type Condition struct {
+	Op     ConditionType
+	Key    string
+	Values []string
+}
+
+// Matches reports whether ctx satisfies c.
+func (c Condition) Matches(ctx EvalCtx) bool {
+	switch c.Op {
+	case StringEquals:
+		return stringMatches(ctx.StringValue(c.Key), c.Values, stringEqual)
+	case StringNotEquals:
+		return !stringMatches(ctx.StringValue(c.Key), c.Values, stringEqual)
+	case StringLike:
+		return stringMatches(ctx.StringValue(c.Key), c.Values, stringLike)
+	case NumericGreaterThan, NumericLessThan:
+		return c.numericMatches(ctx)
+	default:
+		return false
+	}
+}
+
+func (c Condition) numericMatches(ctx EvalCtx) bool {
+	if len(c.Values) == 0 {
+		return false
+	}
+	want, err := strconv.ParseFloat(c.Values[0], 64)
+	if err != nil {
+		return false
+	}
+	got := ctx.NumericValue(c.Key)
+	if c.Op == NumericGreaterThan {
+		return got > want
+	}
+	return got < want
+}
+
+func stringMatches(value string, candidates []string, match func(value, candidate string) bool) bool {
+	for _, candidate := range candidates {
+		if match(value, candidate) {
+			return true
+		}
+	}
+	return false
+}
+
+func stringEqual(value, candidate string) bool {
+	return value == candidate
+}
+
+// stringLike matches value against a pattern where '*' stands for any run of characters; it is
+// deliberately a small glob rather than a full regexp engine, matching the scope of AWS-style
+// StringLike condition operators this Condition is modeled on.
+func stringLike(value, pattern string) bool {
+	parts := strings.Split(pattern, "*")
+	if len(parts) == 1 {
+		return value == pattern
+	}
+	if !strings.HasPrefix(value, parts[0]) {
+		return false
+	}
+	value = value[len(parts[0]):]
+	for _, part := range parts[1 : len(parts)-1] {
+		idx := strings.Index(value, part)
+		if idx < 0 {
+			return false
+		}
+		value = value[idx+len(part):]
+	}
+	return strings.HasSuffix(value, parts[len(parts)-1])
+}
+
+// EvalCtx describes the candidate packet a Chain is evaluated against: the labels and principal
+// of its source and destination endpoints, its L4 details, and the tuple counters accumulated so
+// far (for NumericGreaterThan/NumericLessThan conditions on packets/bytes).
+type EvalCtx struct {
+	SrcLabels    map[string]string
+	DstLabels    map[string]string
+	SrcPrincipal string
+	DstPrincipal string
+	Protocol     string
+	SrcPort      int
+	DstPort      int
+	Packets      int64
+	Bytes        int64
+}
+
+// StringValue resolves key to the string EvalCtx field a Condition's Key refers to. A
+// "label:<name>"/"dstlabel:<name>" key looks up that label on SrcLabels/DstLabels; "principal"/
+// "dstprincipal" and "protocol" return the matching fixed field. An unrecognized key returns "".
+func (ctx EvalCtx) StringValue(key string) string {
+	switch {
+	case key == "principal":
+		return ctx.SrcPrincipal
+	case key == "dstprincipal":
+		return ctx.DstPrincipal
+	case key == "protocol":
+		return ctx.Protocol
+	case strings.HasPrefix(key, "label:"):
+		return ctx.SrcLabels[strings.TrimPrefix(key, "label:")]
+	case strings.HasPrefix(key, "dstlabel:"):
+		return ctx.DstLabels[strings.TrimPrefix(key, "dstlabel:")]
+	default:
+		return ""
+	}
+}
+
+// NumericValue resolves key to the numeric EvalCtx field a Condition's Key refers to: "packets",
+// "bytes", "srcport" or "dstport". An unrecognized key returns 0.
+func (ctx EvalCtx) NumericValue(key string) float64 {
+	switch key {
+	case "packets":
+		return float64(ctx.Packets)
+	case "bytes":
+		return float64(ctx.Bytes)
+	case "srcport":
+		return float64(ctx.SrcPort)
+	case "dstport":
+		return float64(ctx.DstPort)
+	default:
+		return 0
+	}
+}
+
+// Status is Evaluate's verdict for a Chain.
+type Status int
+
+const (
+	// NoRuleFound means no Entry in the Chain had every Condition satisfied.
+	NoRuleFound Status = iota
+	Allow
+	AccessDenied
+)
+
+func (s Status) String() string {
+	switch s {
+	case Allow:
+		return "Allow"
+	case AccessDenied:
+		return "AccessDenied"
+	default:
+		return "NoRuleFound"
+	}
+}
+
+// Entry is one rule in a Chain: the Status it returns if every one of Conditions matches, the
+// RuleID identifying it, and the Conditions themselves. A nil or empty Conditions always matches,
+// for a rule with no conditions beyond the L3/L4 tuple the collector's pending rule trace already
+// filtered on.
+type Entry struct {
+	Status     Status
+	Conditions []Condition
+	RuleID     *calc.RuleID
+}
+
+// Matches reports whether every one of e's Conditions is satisfied by ctx.
+func (e Entry) Matches(ctx EvalCtx) bool {
+	for _, c := range e.Conditions {
+		if !c.Matches(ctx) {
+			return false
+		}
+	}
+	return true
+}
+
+// Chain is an ordered list of Entries, evaluated in order the same way a PolicyStore's
+// tier/policy/rule walk is: the first Entry whose Conditions all match wins.
+type Chain []Entry
+
+// Evaluate returns the Status and RuleID of the first Entry in chain whose Conditions all match
+// ctx, or (NoRuleFound, nil) if none do.
+func Evaluate(chain Chain, ctx EvalCtx) (Status, *calc.RuleID) {
+	for _, entry := range chain {
+		if entry.Matches(ctx) {
+			return entry.Status, entry.RuleID
+		}
+	}
+	return NoRuleFound, nil
+}
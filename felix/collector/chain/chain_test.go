@@ -0,0 +1,98 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chain
+
+import (
+	"testing"
+
+	"github.com/projectcalico/calico/felix/calc"
+)
+
+func TestEvaluate_FirstMatchingEntryWins(t *testing.T) {
+	allow := &calc.RuleID{Name: "allow-db"}
+	deny := &calc.RuleID{Name: "deny-all"}
+	c := Chain{
+		{Status: Allow, RuleID: allow, Conditions: []Condition{
+			{Op: StringEquals, Key: "label:role", Values: []string{"db"}},
+		}},
+		{Status: AccessDenied, RuleID: deny},
+	}
+
+	status, ruleID := Evaluate(c, EvalCtx{SrcLabels: map[string]string{"role": "db"}})
+	if status != Allow || ruleID != allow {
+		t.Fatalf("expected Allow/%v, got %v/%v", allow, status, ruleID)
+	}
+
+	status, ruleID = Evaluate(c, EvalCtx{SrcLabels: map[string]string{"role": "web"}})
+	if status != AccessDenied || ruleID != deny {
+		t.Fatalf("expected AccessDenied/%v, got %v/%v", deny, status, ruleID)
+	}
+}
+
+func TestEvaluate_NoRuleFoundWhenChainExhausted(t *testing.T) {
+	status, ruleID := Evaluate(Chain{
+		{Status: Allow, Conditions: []Condition{{Op: StringEquals, Key: "protocol", Values: []string{"udp"}}}},
+	}, EvalCtx{Protocol: "tcp"})
+	if status != NoRuleFound || ruleID != nil {
+		t.Fatalf("expected NoRuleFound/nil, got %v/%v", status, ruleID)
+	}
+}
+
+func TestCondition_StringLike(t *testing.T) {
+	cases := []struct {
+		pattern string
+		value   string
+		want    bool
+	}{
+		{"arn:aws:iam::*:role/*", "arn:aws:iam::123456789012:role/db-reader", true},
+		{"arn:aws:iam::*:role/*", "arn:aws:iam::123456789012:user/db-reader", false},
+		{"exact", "exact", true},
+		{"exact", "not-exact", false},
+		{"*.svc.cluster.local", "frontend.default.svc.cluster.local", true},
+	}
+	for _, tc := range cases {
+		got := stringLike(tc.value, tc.pattern)
+		if got != tc.want {
+			t.Errorf("stringLike(%q, %q) = %v, want %v", tc.value, tc.pattern, got, tc.want)
+		}
+	}
+}
+
+func TestCondition_NumericGreaterThan(t *testing.T) {
+	c := Condition{Op: NumericGreaterThan, Key: "bytes", Values: []string{"1000"}}
+	if !c.Matches(EvalCtx{Bytes: 2000}) {
+		t.Error("expected 2000 > 1000 to match")
+	}
+	if c.Matches(EvalCtx{Bytes: 500}) {
+		t.Error("expected 500 > 1000 to not match")
+	}
+}
+
+func TestCondition_StringNotEquals(t *testing.T) {
+	c := Condition{Op: StringNotEquals, Key: "dstprincipal", Values: []string{"arn:aws:iam::123:role/trusted"}}
+	if !c.Matches(EvalCtx{DstPrincipal: "arn:aws:iam::123:role/untrusted"}) {
+		t.Error("expected untrusted principal to satisfy StringNotEquals")
+	}
+	if c.Matches(EvalCtx{DstPrincipal: "arn:aws:iam::123:role/trusted"}) {
+		t.Error("expected trusted principal to fail StringNotEquals")
+	}
+}
+
+func TestEntry_MatchesWithNoConditionsAlwaysMatches(t *testing.T) {
+	e := Entry{Status: Allow}
+	if !e.Matches(EvalCtx{}) {
+		t.Error("expected an Entry with no Conditions to always match")
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !windows
+// +build !windows
+
+package collector
+
+import (
+	"strconv"
+
+	"github.com/projectcalico/calico/app-policy/policystore"
+	"github.com/projectcalico/calico/felix/calc"
+	"github.com/projectcalico/calico/felix/collector/chain"
+	"github.com/projectcalico/calico/felix/collector/types/tuple"
+	"github.com/projectcalico/calico/felix/proto"
+	"github.com/projectcalico/calico/felix/rules"
+	"github.com/projectcalico/calico/felix/types"
+)
+
+// buildChain walks ep's Tiers the same way traceRuleChain does, but instead of stopping at the
+// first non-pass action it builds a chain.Chain covering every rule, attaching each proto.Rule's
+// Conditions (alongside the existing selector/port/protocol match fields, to carry the
+// StringEquals/StringLike/NumericGreaterThan predicates EvalCtx tests against) so chain.Evaluate
+// can be used in place of the plain action/pass walk wherever a richer match is needed.
+func buildChain(ps *policystore.PolicyStore, ep *proto.WorkloadEndpoint, dir rules.RuleDirection) chain.Chain {
+	var c chain.Chain
+	for _, tier := range ep.Tiers {
+		policyNames := tier.IngressPolicies
+		if dir == rules.RuleDirEgress {
+			policyNames = tier.EgressPolicies
+		}
+		for _, policyName := range policyNames {
+			policy, ok := ps.PolicyByID[types.PolicyID{Tier: tier.Name, Name: policyName}]
+			if !ok {
+				continue
+			}
+			protoRules := policy.InboundRules
+			if dir == rules.RuleDirEgress {
+				protoRules = policy.OutboundRules
+			}
+			for ruleIdx, rule := range protoRules {
+				ruleID := calc.NewRuleID(tier.Name, policyName, "", ruleIdx, dir, protoRuleAction(rule.Action))
+				c = append(c, chain.Entry{
+					Status:     protoRuleStatus(rule.Action),
+					Conditions: protoRuleConditions(rule),
+					RuleID:     ruleID,
+				})
+			}
+		}
+	}
+	return c
+}
+
+// protoRuleStatus maps a proto.Rule's string Action to the chain.Status chain.Evaluate returns
+// when that rule is the first full match.
+func protoRuleStatus(action string) chain.Status {
+	if action == "deny" {
+		return chain.AccessDenied
+	}
+	return chain.Allow
+}
+
+// protoRuleConditions translates rule.Conditions (see buildChain's doc comment) into
+// chain.Conditions, so a rule with no conditions beyond the tuple match the collector already
+// performed degrades to an Entry that always matches, the same as before this evaluation layer
+// existed.
+func protoRuleConditions(rule *proto.Rule) []chain.Condition {
+	conditions := make([]chain.Condition, 0, len(rule.Conditions))
+	for _, cond := range rule.Conditions {
+		conditions = append(conditions, chain.Condition{
+			Op:     chain.ConditionType(cond.Op),
+			Key:    cond.Key,
+			Values: cond.Values,
+		})
+	}
+	return conditions
+}
+
+// evalCtxForTuple assembles the chain.EvalCtx a live packet's Data is evaluated against:
+// source/destination labels and service-account principals resolved from c.lookupsCache, and the
+// L4/counter fields chain.Evaluate's NumericGreaterThan/NumericLessThan conditions compare
+// against (Value() is counter.Counter's plain accessor for the accumulated count).
+func (c *collector) evalCtxForTuple(t tuple.Tuple, data *Data) chain.EvalCtx {
+	srcLabels, srcPrincipal := c.lookupsCache.EndpointLabelsAndPrincipal(t.Src)
+	dstLabels, dstPrincipal := c.lookupsCache.EndpointLabelsAndPrincipal(t.Dst)
+	return chain.EvalCtx{
+		SrcLabels:    srcLabels,
+		DstLabels:    dstLabels,
+		SrcPrincipal: srcPrincipal,
+		DstPrincipal: dstPrincipal,
+		Protocol:     strconv.Itoa(int(t.Proto)),
+		SrcPort:      t.L4Src.Port,
+		DstPort:      t.L4Dst.Port,
+		Packets:      int64(data.ConntrackPacketsCounter().Value()),
+		Bytes:        int64(data.ConntrackBytesCounter().Value()),
+	}
+}
@@ -26,6 +26,7 @@ import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	kapiv1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 
@@ -928,7 +929,7 @@ var _ = Describe("Conntrack Datasource", func() {
 			// Flag the data as reported, remove endpoints from mock data and send in CT entry again.
 			data := c.epStats[*t]
 			data.Reported = true
-			lm.SetMockData(epMapDelete, nil, nil, nil)
+			lm.SetMockData(epMapDelete, nil, nil, nil, nil)
 			ciReaderSenderChan <- []clttypes.ConntrackInfo{convertCtEntry(inCtEntry, 0)}
 
 			// This is a reported flow, and is a conntrack update - this should not impact the stored data at all.
@@ -942,7 +943,7 @@ var _ = Describe("Conntrack Datasource", func() {
 			Eventually(c.epStats, "500ms", "100ms").Should(HaveKey(*t))
 
 			// Data is not reported. Remove endpoints from mock data and send in CT entry again.
-			lm.SetMockData(epMapDelete, nil, nil, nil)
+			lm.SetMockData(epMapDelete, nil, nil, nil, nil)
 			ciReaderSenderChan <- []clttypes.ConntrackInfo{convertCtEntry(inCtEntry, 0)}
 
 			// This is an unreported flow, and is a conntrack update. We can update the endpoint, but we never downgrade
@@ -963,7 +964,7 @@ var _ = Describe("Conntrack Datasource", func() {
 			oldSrc := data.SrcEp
 			oldDest := data.DstEp
 
-			lm.SetMockData(epMapSwapLocal, nil, nil, nil)
+			lm.SetMockData(epMapSwapLocal, nil, nil, nil, nil)
 			ciReaderSenderChan <- []clttypes.ConntrackInfo{convertCtEntry(inCtEntry, 0)}
 
 			// This is a reported flow, and is a conntrack update - this should not impact the stored data at all since
@@ -984,7 +985,7 @@ var _ = Describe("Conntrack Datasource", func() {
 			oldSrc := data.SrcEp
 			oldDest := data.DstEp
 
-			lm.SetMockData(epMapSwapLocal, nil, nil, nil)
+			lm.SetMockData(epMapSwapLocal, nil, nil, nil, nil)
 			ciReaderSenderChan <- []clttypes.ConntrackInfo{convertCtEntry(inCtEntry, 0)}
 
 			// This is an unreported flow, and is a conntrack update. We can update the endpoint.
@@ -1002,7 +1003,7 @@ var _ = Describe("Conntrack Datasource", func() {
 			// Flag the data as reported, remove endpoints from mock data and send in packetinfo entry again.
 			data := c.epStats[*t]
 			data.Reported = true
-			lm.SetMockData(epMapDelete, nil, nil, nil)
+			lm.SetMockData(epMapDelete, nil, nil, nil, nil)
 			c.applyPacketInfo(pktinfo)
 
 			// This is a reported flow but we are going through packet processing still. It should be expired and
@@ -1021,7 +1022,7 @@ var _ = Describe("Conntrack Datasource", func() {
 			data := c.epStats[*t]
 			oldSrc := data.SrcEp
 			oldDest := data.DstEp
-			lm.SetMockData(epMapDelete, nil, nil, nil)
+			lm.SetMockData(epMapDelete, nil, nil, nil, nil)
 			c.applyPacketInfo(pktinfo)
 
 			// This is an unreported flow but we are going through packet processing still. However, since the endpoint
@@ -1044,7 +1045,7 @@ var _ = Describe("Conntrack Datasource", func() {
 			oldSrc := data.SrcEp
 			oldDest := data.DstEp
 
-			lm.SetMockData(epMapSwapLocal, nil, nil, nil)
+			lm.SetMockData(epMapSwapLocal, nil, nil, nil, nil)
 			c.applyPacketInfo(pktinfo)
 
 			// This is a reported flow but we are going through packet processing still. It should be expired and
@@ -1066,7 +1067,7 @@ var _ = Describe("Conntrack Datasource", func() {
 			oldSrc := data.SrcEp
 			oldDest := data.DstEp
 
-			lm.SetMockData(epMapSwapLocal, nil, nil, nil)
+			lm.SetMockData(epMapSwapLocal, nil, nil, nil, nil)
 			c.applyPacketInfo(pktinfo)
 
 			// This is an unreported flow, and is a conntrack update. We can update the endpoint.
@@ -1126,7 +1127,7 @@ var _ = Describe("Conntrack Datasource", func() {
 			// Flag the data as reported, remove endpoints from mock data and send in CT entry again.
 			data := c.epStats[*t]
 			data.Reported = true
-			lm.SetMockData(epMapDelete, nil, nil, nil)
+			lm.SetMockData(epMapDelete, nil, nil, nil, nil)
 			ciReaderSenderChan <- []clttypes.ConntrackInfo{convertCtEntry(outCtEntry, 0)}
 
 			// This is a reported flow, and is a conntrack update - this should not impact the stored data at all.
@@ -1140,7 +1141,7 @@ var _ = Describe("Conntrack Datasource", func() {
 			Eventually(c.epStats, "500ms", "100ms").Should(HaveKey(*t))
 
 			// Data is not reported. Remove endpoints from mock data and send in CT entry again.
-			lm.SetMockData(epMapDelete, nil, nil, nil)
+			lm.SetMockData(epMapDelete, nil, nil, nil, nil)
 			ciReaderSenderChan <- []clttypes.ConntrackInfo{convertCtEntry(outCtEntry, 0)}
 
 			// This is an unreported flow, and is a conntrack update. We can update the endpoint, but we never downgrade
@@ -1161,7 +1162,7 @@ var _ = Describe("Conntrack Datasource", func() {
 			oldSrc := data.SrcEp
 			oldDest := data.DstEp
 
-			lm.SetMockData(epMapSwapLocal, nil, nil, nil)
+			lm.SetMockData(epMapSwapLocal, nil, nil, nil, nil)
 			ciReaderSenderChan <- []clttypes.ConntrackInfo{convertCtEntry(outCtEntry, 0)}
 
 			// This is a reported flow, and is a conntrack update - this should not impact the stored data at all since
@@ -1182,7 +1183,7 @@ var _ = Describe("Conntrack Datasource", func() {
 			oldSrc := data.SrcEp
 			oldDest := data.DstEp
 
-			lm.SetMockData(epMapSwapLocal, nil, nil, nil)
+			lm.SetMockData(epMapSwapLocal, nil, nil, nil, nil)
 			ciReaderSenderChan <- []clttypes.ConntrackInfo{convertCtEntry(outCtEntry, 0)}
 
 			// This is an unreported flow, and is a conntrack update. We can update the endpoint.
@@ -1200,7 +1201,7 @@ var _ = Describe("Conntrack Datasource", func() {
 			// Flag the data as reported, remove endpoints from mock data and send in packetinfo entry again.
 			data := c.epStats[*t]
 			data.Reported = true
-			lm.SetMockData(epMapDelete, nil, nil, nil)
+			lm.SetMockData(epMapDelete, nil, nil, nil, nil)
 			c.applyPacketInfo(pktinfo)
 
 			// This is a reported flow but we are going through packet processing still. It should be expired and
@@ -1219,7 +1220,7 @@ var _ = Describe("Conntrack Datasource", func() {
 			data := c.epStats[*t]
 			oldSrc := data.SrcEp
 			oldDest := data.DstEp
-			lm.SetMockData(epMapDelete, nil, nil, nil)
+			lm.SetMockData(epMapDelete, nil, nil, nil, nil)
 			c.applyPacketInfo(pktinfo)
 
 			// This is an unreported flow but we are going through packet processing still. However, since the endpoint
@@ -1242,7 +1243,7 @@ var _ = Describe("Conntrack Datasource", func() {
 			oldSrc := data.SrcEp
 			oldDest := data.DstEp
 
-			lm.SetMockData(epMapSwapLocal, nil, nil, nil)
+			lm.SetMockData(epMapSwapLocal, nil, nil, nil, nil)
 			c.applyPacketInfo(pktinfo)
 
 			// This is a reported flow but we are going through packet processing still. It should be expired and
@@ -1264,7 +1265,7 @@ var _ = Describe("Conntrack Datasource", func() {
 			oldSrc := data.SrcEp
 			oldDest := data.DstEp
 
-			lm.SetMockData(epMapSwapLocal, nil, nil, nil)
+			lm.SetMockData(epMapSwapLocal, nil, nil, nil, nil)
 			c.applyPacketInfo(pktinfo)
 
 			// This is an unreported flow, and is a conntrack update. We can update the endpoint.
@@ -1505,12 +1506,62 @@ var _ = Describe("Conntrack Datasource", func() {
 					},
 				},
 				},
-			})
+			}, nil)
 
 			By("handling another nflog update for destination matching on policy - should rematch and expire the entry")
 			c.applyPacketInfo(nflogReader.ConvertNflogPkt(rules.RuleDirIngress, localPktIngress[localPktIngressNflogTuple]))
 			Expect(c.epStats).ShouldNot(HaveKey(*t))
 		})
+		It("handle pre-DNAT info on conntrack for a headless service", func() {
+			By("handling a conntrack update for a flow DNAT'd straight to a pod IP, as headless services do")
+			t := tuple.New(localIp1, localIp2, proto_tcp, srcPort, dstPort)
+			ciReaderSenderChan <- []clttypes.ConntrackInfo{convertCtEntry(localCtEntryWithDNAT, 0)}
+			Eventually(c.epStats, "500ms", "100ms").Should(HaveKey(*t))
+
+			By("flagging the data as expired")
+			data := c.epStats[*t]
+			data.Expired = true
+			Expect(data.IsDNAT).Should(BeTrue())
+
+			By("handling nflog updates for destination matching on policy - all policy info is now gathered, but no service")
+			c.applyPacketInfo(nflogReader.ConvertNflogPkt(rules.RuleDirIngress, localPktIngress[localPktIngressNflogTuple]))
+			c.applyPacketInfo(nflogReader.ConvertNflogPkt(rules.RuleDirEgress, localPktEgress[localPktEgressNflogTuple]))
+			Eventually(c.epStats, "500ms", "100ms").Should(HaveKey(*t))
+
+			By("creating a headless service whose EndpointSlice backs the post-DNAT pod IP:port directly, with no ClusterIP to match on")
+			lm.SetMockData(nil, nil, nil,
+				map[model.ResourceKey]*kapiv1.Service{
+					{Kind: model.KindKubernetesService, Name: "headless-svc", Namespace: "default"}: {Spec: kapiv1.ServiceSpec{
+						ClusterIP: kapiv1.ClusterIPNone,
+						Ports: []kapiv1.ServicePort{{
+							Name:       "test",
+							Protocol:   kapiv1.ProtocolTCP,
+							Port:       int32(dstPortDNAT),
+							TargetPort: intstr.FromInt(int(dstPort)),
+						}},
+					}},
+				},
+				map[model.ResourceKey]*discoveryv1.EndpointSlice{
+					{Kind: model.KindKubernetesEndpointSlice, Name: "headless-svc-abcde", Namespace: "default"}: {
+						ObjectMeta: metav1.ObjectMeta{
+							Labels: map[string]string{discoveryv1.LabelServiceName: "headless-svc"},
+						},
+						AddressType: discoveryv1.AddressTypeIPv4,
+						Endpoints: []discoveryv1.Endpoint{{
+							Addresses: []string{localIp2Str},
+						}},
+						Ports: []discoveryv1.EndpointPort{{
+							Name: stringPtr("test"),
+							Port: int32Ptr(int32(dstPort)),
+						}},
+					},
+				},
+			)
+
+			By("handling another nflog update for destination matching on policy - should rematch against the EndpointSlice-backed service and expire the entry")
+			c.applyPacketInfo(nflogReader.ConvertNflogPkt(rules.RuleDirIngress, localPktIngress[localPktIngressNflogTuple]))
+			Expect(c.epStats).ShouldNot(HaveKey(*t))
+		})
 		It("handle pre-DNAT info on nflog update", func() {
 			By("handling egress nflog updates for destination matching on policy - this contains pre-DNAT info")
 			t := tuple.New(localIp1, localIp2, proto_tcp, srcPort, dstPort)
@@ -1540,7 +1591,7 @@ var _ = Describe("Conntrack Datasource", func() {
 					},
 				},
 				},
-			})
+			}, nil)
 
 			By("handling another nflog update for destination matching on policy - should rematch and expire the entry")
 			c.applyPacketInfo(nflogReader.ConvertNflogPkt(rules.RuleDirIngress, localPktIngress[localPktIngressNflogTuple]))
@@ -1704,10 +1755,13 @@ func newMockLookupsCache(
 	svcs map[model.ResourceKey]*kapiv1.Service,
 ) *calc.LookupsCache {
 	l := calc.NewLookupsCache()
-	l.SetMockData(em, nm, ns, svcs)
+	l.SetMockData(em, nm, ns, svcs, nil)
 	return l
 }
 
+func stringPtr(s string) *string { return &s }
+func int32Ptr(i int32) *int32    { return &i }
+
 // Define a separate metric type that doesn't include the actual stats.  We use this
 // for simpler comparisons.
 type testMetricUpdate struct {
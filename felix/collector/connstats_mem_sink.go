@@ -0,0 +1,61 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !windows
+// +build !windows
+
+package collector
+
+import (
+	"sync"
+
+	clttypes "github.com/projectcalico/calico/felix/collector/types"
+)
+
+// MemConnStatsSink is a ConnStatsSink tests can inspect directly: it keeps the last capacity
+// snapshots published, in publish order, so a test can assert both content and emission
+// ordering without standing up a real transport.
+type MemConnStatsSink struct {
+	mu       sync.Mutex
+	capacity int
+	entries  []clttypes.ConnStatsSnapshot
+}
+
+// NewMemConnStatsSink returns an empty MemConnStatsSink that retains at most capacity snapshots,
+// discarding the oldest once full. capacity <= 0 means unbounded.
+func NewMemConnStatsSink(capacity int) *MemConnStatsSink {
+	return &MemConnStatsSink{capacity: capacity}
+}
+
+func (s *MemConnStatsSink) Publish(snapshot clttypes.ConnStatsSnapshot) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries = append(s.entries, snapshot)
+	if s.capacity > 0 && len(s.entries) > s.capacity {
+		s.entries = s.entries[len(s.entries)-s.capacity:]
+	}
+}
+
+// Entries returns a copy of the snapshots currently retained, oldest first.
+func (s *MemConnStatsSink) Entries() []clttypes.ConnStatsSnapshot {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make([]clttypes.ConnStatsSnapshot, len(s.entries))
+	copy(out, s.entries)
+	return out
+}
+
+func (s *MemConnStatsSink) Close() error {
+	return nil
+}
@@ -0,0 +1,110 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !windows
+// +build !windows
+
+package collector
+
+import (
+	"net"
+
+	clttypes "github.com/projectcalico/calico/felix/collector/types"
+)
+
+// ConnStatsSink receives a ConnStatsSnapshot for every tracked conntrack flow the AuditLogger
+// pipeline observes, independent of (and in addition to) the AuditSink/IPFIXExporter audit
+// trail: MemConnStatsSink and StreamConnStatsSink are the two built-in implementations.
+type ConnStatsSink interface {
+	Publish(clttypes.ConnStatsSnapshot)
+	Close() error
+}
+
+// connStatsState is what AuditLogger remembers per 5-tuple so it can turn a conntrack entry's
+// cumulative counters into deltas, and so a flow's resolved endpoint identity survives an update
+// that can no longer resolve it.
+type connStatsState struct {
+	orig, reply  clttypes.Counters
+	srcEp, dstEp string
+}
+
+// SetConnStatsSink attaches sink as the destination for per-connection telemetry snapshots. Call
+// it before ConsumeFrom/SetConntrackBackend so no early updates race its first Publish.
+func (a *AuditLogger) SetConnStatsSink(sink ConnStatsSink) {
+	a.connStatsMu.Lock()
+	defer a.connStatsMu.Unlock()
+	a.connStatsSink = sink
+	if a.connStatsState == nil {
+		a.connStatsState = map[fiveTupleKey]connStatsState{}
+	}
+}
+
+// publishConnStats computes this update's packet/byte deltas against the last absolute counters
+// seen for key, merges srcEp/dstEp into whatever endpoint identity is already known for key
+// (never overwriting a known identity with an unknown one), and forwards the resulting snapshot
+// to the configured ConnStatsSink. It's a no-op if no sink is attached.
+func (a *AuditLogger) publishConnStats(
+	key fiveTupleKey,
+	srcIP, dstIP net.IP, srcPort, dstPort, protocol int,
+	srcEp, dstEp string,
+	natOutgoingPort int,
+	orig, reply clttypes.Counters,
+) {
+	a.connStatsMu.Lock()
+	sink := a.connStatsSink
+	if sink == nil {
+		a.connStatsMu.Unlock()
+		return
+	}
+	if a.connStatsState == nil {
+		a.connStatsState = map[fiveTupleKey]connStatsState{}
+	}
+	state := a.connStatsState[key]
+	if srcEp != "" {
+		state.srcEp = srcEp
+	}
+	if dstEp != "" {
+		state.dstEp = dstEp
+	}
+	prevOrig, prevReply := state.orig, state.reply
+	state.orig, state.reply = orig, reply
+	a.connStatsState[key] = state
+	a.connStatsMu.Unlock()
+
+	snapshot := clttypes.ConnStatsSnapshot{
+		SrcIP:           srcIP,
+		DstIP:           dstIP,
+		SrcPort:         srcPort,
+		DstPort:         dstPort,
+		Protocol:        protocol,
+		SrcEndpoint:     state.srcEp,
+		DstEndpoint:     state.dstEp,
+		NatOutgoingPort: natOutgoingPort,
+		TxPackets:       counterDelta(orig.Packets, prevOrig.Packets),
+		TxBytes:         counterDelta(orig.Bytes, prevOrig.Bytes),
+		RxPackets:       counterDelta(reply.Packets, prevReply.Packets),
+		RxBytes:         counterDelta(reply.Bytes, prevReply.Bytes),
+	}
+	sink.Publish(snapshot)
+}
+
+// counterDelta returns cur-prev, or cur itself if cur < prev: a lower reading than last time
+// means the underlying conntrack entry was replaced (NAT port reuse, zone rollover) rather than
+// that traffic ran backwards, so the new absolute value is itself the delta for this snapshot.
+func counterDelta(cur, prev int) int {
+	if cur < prev {
+		return cur
+	}
+	return cur - prev
+}
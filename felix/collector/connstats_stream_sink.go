@@ -0,0 +1,69 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !windows
+// +build !windows
+
+package collector
+
+import (
+	"encoding/json"
+	"net"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+
+	clttypes "github.com/projectcalico/calico/felix/collector/types"
+)
+
+// StreamConnStatsSink streams one JSON-encoded ConnStatsSnapshot per line over a long-lived Unix
+// domain socket connection, so an out-of-process aggregator on the same host can consume
+// per-connection telemetry without Felix going through the full flow-log aggregation path. A
+// gRPC transport would suit a remote aggregator better, but would be the first gRPC dependency
+// anywhere in Felix; this tree's other streaming sinks (SyslogAuditSink, FileAuditSink) are all
+// plain socket/file writers, so a Unix socket keeps ConnStatsSink in the same style.
+type StreamConnStatsSink struct {
+	mu   sync.Mutex
+	conn net.Conn
+}
+
+// NewStreamConnStatsSink dials the Unix domain socket at path; the peer is expected to already be
+// listening (Felix is the client here, not the server, the same way SyslogAuditSink dials out
+// rather than accepting connections).
+func NewStreamConnStatsSink(path string) (*StreamConnStatsSink, error) {
+	conn, err := net.Dial("unix", path)
+	if err != nil {
+		return nil, err
+	}
+	return &StreamConnStatsSink{conn: conn}, nil
+}
+
+func (s *StreamConnStatsSink) Publish(snapshot clttypes.ConnStatsSnapshot) {
+	line, err := json.Marshal(snapshot)
+	if err != nil {
+		log.WithError(err).Warn("StreamConnStatsSink: failed to marshal snapshot")
+		return
+	}
+	line = append(line, '\n')
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, err := s.conn.Write(line); err != nil {
+		log.WithError(err).Warn("StreamConnStatsSink: failed to write snapshot")
+	}
+}
+
+func (s *StreamConnStatsSink) Close() error {
+	return s.conn.Close()
+}
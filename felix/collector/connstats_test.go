@@ -0,0 +1,119 @@
+//go:build !windows
+// +build !windows
+
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"net"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/projectcalico/calico/felix/calc"
+	clttypes "github.com/projectcalico/calico/felix/collector/types"
+	"github.com/projectcalico/calico/felix/nfnetlink"
+	"github.com/projectcalico/calico/felix/rules"
+)
+
+var _ = Describe("ConnStatsSink", func() {
+	const (
+		srcIPStr = "10.0.0.1"
+		dstIPStr = "20.0.0.1"
+		srcPort  = 20000
+		dstPort  = 80
+		proto    = 6
+	)
+
+	var (
+		a    *AuditLogger
+		sink *MemConnStatsSink
+		agg  *nfnetlink.NflogPacketAggregate
+		key  fiveTupleKey
+	)
+
+	BeforeEach(func() {
+		a = NewAuditLogger(calc.NewLookupsCache(), nil, 1.0, 0, 0)
+		sink = NewMemConnStatsSink(0)
+		a.SetConnStatsSink(sink)
+
+		agg = &nfnetlink.NflogPacketAggregate{
+			Tuple: nfnetlink.NflogPacketTuple{
+				Src:   ipTo16Byte(srcIPStr),
+				Dst:   ipTo16Byte(dstIPStr),
+				Proto: proto,
+				L4Src: nfnetlink.NflogL4Info{Port: srcPort},
+				L4Dst: nfnetlink.NflogL4Info{Port: dstPort},
+			},
+			Prefixes: []nfnetlink.NflogPrefix{{Bytes: 100, Packets: 1}},
+		}
+		key = fiveTupleKeyFor(net.ParseIP(srcIPStr), net.ParseIP(dstIPStr), srcPort, dstPort, proto)
+	})
+
+	It("publishes nothing when no conntrack entry is known for the 5-tuple", func() {
+		ruleID := &calc.RuleID{PolicyID: calc.PolicyID{Tier: "default", Name: "p"}, IndexStr: "0", Action: rules.RuleActionAllow}
+		a.buildRecord(rules.RuleDirIngress, agg, agg.Prefixes[0], ruleID)
+		Expect(sink.Entries()).To(BeEmpty())
+	})
+
+	It("reports packet/byte deltas, not the conntrack entry's cumulative totals", func() {
+		a.ctInfo = map[fiveTupleKey]clttypes.ConntrackInfo{
+			key: {
+				SrcIP: net.ParseIP(srcIPStr), DstIP: net.ParseIP(dstIPStr),
+				SrcPort: srcPort, DstPort: dstPort, Protocol: proto,
+				OrigCounters:  clttypes.Counters{Packets: 10, Bytes: 1000},
+				ReplyCounters: clttypes.Counters{Packets: 4, Bytes: 400},
+			},
+		}
+		ruleID := &calc.RuleID{PolicyID: calc.PolicyID{Tier: "default", Name: "p"}, IndexStr: "0", Action: rules.RuleActionAllow}
+
+		a.buildRecord(rules.RuleDirIngress, agg, agg.Prefixes[0], ruleID)
+
+		a.ctInfo[key] = clttypes.ConntrackInfo{
+			SrcIP: net.ParseIP(srcIPStr), DstIP: net.ParseIP(dstIPStr),
+			SrcPort: srcPort, DstPort: dstPort, Protocol: proto,
+			OrigCounters:  clttypes.Counters{Packets: 15, Bytes: 1500},
+			ReplyCounters: clttypes.Counters{Packets: 6, Bytes: 600},
+		}
+		a.buildRecord(rules.RuleDirIngress, agg, agg.Prefixes[0], ruleID)
+
+		entries := sink.Entries()
+		Expect(entries).To(HaveLen(2))
+		Expect(entries[0].TxPackets).To(Equal(10))
+		Expect(entries[0].RxPackets).To(Equal(4))
+		Expect(entries[1].TxPackets).To(Equal(5))
+		Expect(entries[1].RxPackets).To(Equal(2))
+	})
+
+	It("doesn't downgrade a resolved endpoint identity when a later update can't resolve one", func() {
+		a.ctInfo = map[fiveTupleKey]clttypes.ConntrackInfo{
+			key: {SrcIP: net.ParseIP(srcIPStr), DstIP: net.ParseIP(dstIPStr), SrcPort: srcPort, DstPort: dstPort, Protocol: proto},
+		}
+
+		resolved := AuditRecord{SrcEndpoint: "src-wep"}
+		a.applyConntrackInfo(&resolved, agg)
+
+		// A later update for the same 5-tuple where the endpoint could no longer be resolved
+		// (e.g. the workload was deleted) must not blank out the identity already published.
+		unresolved := AuditRecord{}
+		a.applyConntrackInfo(&unresolved, agg)
+
+		entries := sink.Entries()
+		Expect(entries).To(HaveLen(2))
+		Expect(entries[0].SrcEndpoint).To(Equal("src-wep"))
+		Expect(entries[1].SrcEndpoint).To(Equal("src-wep"))
+	})
+})
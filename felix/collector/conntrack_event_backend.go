@@ -0,0 +1,158 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !windows
+// +build !windows
+
+package collector
+
+import (
+	"context"
+
+	log "github.com/sirupsen/logrus"
+
+	clttypes "github.com/projectcalico/calico/felix/collector/types"
+	"github.com/projectcalico/calico/felix/nfnetlink"
+)
+
+// ConntrackEventType is the kernel conntrack multicast event a conntrackEvent carries, mirroring
+// the NFNLGRP_CONNTRACK_NEW/UPDATE/DESTROY groups EventConntrackBackend subscribes to.
+type ConntrackEventType int
+
+const (
+	ConntrackEventNew ConntrackEventType = iota
+	ConntrackEventUpdate
+	ConntrackEventDestroy
+)
+
+// conntrackEvent pairs a raw conntrack entry with the multicast group it arrived on; kept
+// unexported so dedupeConntrackEvents can be driven directly by tests without a real netlink
+// socket.
+type conntrackEvent struct {
+	Type  ConntrackEventType
+	Entry nfnetlink.CtEntry
+}
+
+// EventConntrackBackend is an alternative to NetlinkConntrackBackend for deployments that need
+// sub-second visibility into short-lived flows: instead of waiting for the next periodic
+// Dump/poll, it subscribes to the kernel's NFNLGRP_CONNTRACK_NEW, NFNLGRP_CONNTRACK_UPDATE and
+// NFNLGRP_CONNTRACK_DESTROY multicast groups and emits a ConntrackInfo as soon as each event
+// arrives, so DNAT/SNAT translation and reply-direction counters reach the collector before a
+// flow ages out of the kernel's own conntrack table.
+type EventConntrackBackend struct{}
+
+// NewEventConntrackBackend returns a ConntrackBackend backed by conntrack event notifications
+// rather than periodic table scans.
+func NewEventConntrackBackend() *EventConntrackBackend {
+	return &EventConntrackBackend{}
+}
+
+// Dump returns a point-in-time snapshot the same way NetlinkConntrackBackend.Dump does; event
+// notifications alone can't answer "what's live right now" for a reader that just started, so a
+// consumer typically calls Dump once at startup and then relies on Subscribe for updates.
+func (b *EventConntrackBackend) Dump() ([]clttypes.ConntrackInfo, error) {
+	entries, err := nfnetlink.ConntrackList()
+	if err != nil {
+		return nil, err
+	}
+	infos := make([]clttypes.ConntrackInfo, 0, len(entries))
+	for _, entry := range entries {
+		infos = append(infos, ConvertCtEntryToConntrackInfo(entry))
+	}
+	return infos, nil
+}
+
+func (b *EventConntrackBackend) Subscribe(ctx context.Context) <-chan clttypes.ConntrackInfo {
+	events, err := nfnetlink.ConntrackEventUpdates(ctx)
+	if err != nil {
+		log.WithError(err).Error("EventConntrackBackend: failed to subscribe to conntrack multicast groups")
+		out := make(chan clttypes.ConntrackInfo)
+		close(out)
+		return out
+	}
+
+	raw := make(chan conntrackEvent, 1000)
+	go func() {
+		defer close(raw)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case ev, ok := <-events:
+				if !ok {
+					return
+				}
+				raw <- conntrackEvent{Type: ConntrackEventType(ev.Type), Entry: ev.Entry}
+			}
+		}
+	}()
+
+	return dedupeConntrackEvents(ctx, raw)
+}
+
+// zoneTupleKey identifies a conntrack entry by 5-tuple and zone, the granularity
+// dedupeConntrackEvents tracks open flows at.
+type zoneTupleKey struct {
+	fiveTupleKey
+	zone int
+}
+
+func zoneTupleKeyFor(info clttypes.ConntrackInfo) zoneTupleKey {
+	return zoneTupleKey{
+		fiveTupleKey: fiveTupleKeyFor(info.SrcIP, info.DstIP, info.SrcPort, info.DstPort, info.Protocol),
+		zone:         info.Zone,
+	}
+}
+
+// dedupeConntrackEvents converts each conntrackEvent to a ConntrackInfo and forwards it, except
+// that a repeated NEW for a (tuple, zone) already tracked as open is dropped -- the multicast
+// transport can redeliver NEW notifications (e.g. after a netlink ENOBUFS resync), and without
+// this the collector would double-count the flow's initial counters. UPDATE and DESTROY are
+// always forwarded: UPDATE because its counters supersede whatever was last seen, and DESTROY
+// because it carries the flow's final reply-direction counters and must reach the collector even
+// if this reader never saw (or already dropped) the matching NEW.
+func dedupeConntrackEvents(ctx context.Context, events <-chan conntrackEvent) <-chan clttypes.ConntrackInfo {
+	out := make(chan clttypes.ConntrackInfo, 1000)
+	go func() {
+		defer close(out)
+		open := map[zoneTupleKey]struct{}{}
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case ev, ok := <-events:
+				if !ok {
+					return
+				}
+				info := ConvertCtEntryToConntrackInfo(ev.Entry)
+				key := zoneTupleKeyFor(info)
+
+				switch ev.Type {
+				case ConntrackEventNew:
+					if _, seen := open[key]; seen {
+						continue
+					}
+					open[key] = struct{}{}
+				case ConntrackEventDestroy:
+					info.Expired = true
+					delete(open, key)
+				default: // ConntrackEventUpdate
+					open[key] = struct{}{}
+				}
+				out <- info
+			}
+		}
+	}()
+	return out
+}
@@ -0,0 +1,94 @@
+//go:build !windows
+// +build !windows
+
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	clttypes "github.com/projectcalico/calico/felix/collector/types"
+	"github.com/projectcalico/calico/felix/nfnetlink"
+	"github.com/projectcalico/calico/felix/nfnetlink/nfnl"
+)
+
+var _ = Describe("dedupeConntrackEvents", func() {
+	var (
+		ctx    context.Context
+		cancel context.CancelFunc
+		raw    chan conntrackEvent
+		out    <-chan clttypes.ConntrackInfo
+	)
+
+	BeforeEach(func() {
+		ctx, cancel = context.WithCancel(context.Background())
+		raw = make(chan conntrackEvent, 10)
+		out = dedupeConntrackEvents(ctx, raw)
+	})
+
+	AfterEach(func() {
+		cancel()
+	})
+
+	It("emits a flow on NEW", func() {
+		raw <- conntrackEvent{Type: ConntrackEventNew, Entry: localCtEntryIPv6}
+
+		info := <-out
+		Expect(info.SrcIP.String()).To(Equal(remoteIP6v1.String()))
+		Expect(info.Expired).To(BeFalse())
+	})
+
+	It("drops a repeated NEW for the same tuple+zone", func() {
+		raw <- conntrackEvent{Type: ConntrackEventNew, Entry: localCtEntryIPv6}
+		<-out
+
+		raw <- conntrackEvent{Type: ConntrackEventNew, Entry: localCtEntryIPv6}
+		raw <- conntrackEvent{Type: ConntrackEventUpdate, Entry: bumpedCounters(localCtEntryIPv6)}
+
+		// Only the UPDATE should come through; the duplicate NEW is dropped silently.
+		info := <-out
+		Expect(info.OrigCounters.Packets).To(Equal(5))
+	})
+
+	It("finalizes a flow on DESTROY with the reply-direction counters", func() {
+		raw <- conntrackEvent{Type: ConntrackEventNew, Entry: localCtEntryIPv6}
+		<-out
+
+		destroyed := localCtEntryIPv6
+		destroyed.OriginalCounters = nfnetlink.CtCounters{Packets: 9, Bytes: 900}
+		destroyed.ReplyCounters = nfnetlink.CtCounters{Packets: 7, Bytes: 700}
+		destroyed.ProtoInfo = nfnetlink.CtProtoInfo{State: nfnl.TCP_CONNTRACK_CLOSE}
+		raw <- conntrackEvent{Type: ConntrackEventDestroy, Entry: destroyed}
+
+		info := <-out
+		Expect(info.Expired).To(BeTrue())
+		Expect(info.OrigCounters.Packets).To(Equal(9))
+		Expect(info.ReplyCounters.Packets).To(Equal(7))
+
+		// A NEW for the same tuple+zone after DESTROY is treated as a fresh flow, not a dup.
+		raw <- conntrackEvent{Type: ConntrackEventNew, Entry: localCtEntryIPv6}
+		info = <-out
+		Expect(info.Expired).To(BeFalse())
+	})
+})
+
+func bumpedCounters(entry nfnetlink.CtEntry) nfnetlink.CtEntry {
+	entry.OriginalCounters = nfnetlink.CtCounters{Packets: 5, Bytes: 500}
+	return entry
+}
@@ -0,0 +1,176 @@
+//go:build !windows
+// +build !windows
+
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"net"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/projectcalico/calico/felix/calc"
+	clttypes "github.com/projectcalico/calico/felix/collector/types"
+	"github.com/projectcalico/calico/felix/nfnetlink"
+	"github.com/projectcalico/calico/felix/nfnetlink/nfnl"
+	"github.com/projectcalico/calico/felix/rules"
+)
+
+// These IPv6 fixtures mirror the IPv4 "local destination"/"local source"/"DNAT" scenarios in
+// collector_test.go, but exercise ConvertCtEntryToConntrackInfo and AuditLogger.applyConntrackInfo
+// directly: unlike that file's Collector/epStats pipeline (which this tree doesn't build), both
+// of those already key and store IPs as net.IP rather than a 4-byte-assuming fixed array, so no
+// family-specific branch was needed to carry an IPv6 5-tuple through.
+var (
+	localIP6v1  = net.ParseIP("fd00::1")
+	localIP6v2  = net.ParseIP("fd00::2")
+	remoteIP6v1 = net.ParseIP("2001:db8::1")
+
+	// localIP6v1DNAT is the service VIP a client dials before DNAT rewrites it to localIP6v1 --
+	// the IPv6 analogue of localIp1DNAT in collector_test.go.
+	localIP6v1DNAT = net.ParseIP("fd00:ffff::1")
+)
+
+const (
+	ip6SrcPort    = 30000
+	ip6DstPort    = 443
+	ip6DstPortVIP = 8443
+)
+
+var localCtEntryIPv6 = nfnetlink.CtEntry{
+	OriginalTuple: nfnetlink.CtTuple{
+		Src:      remoteIP6v1,
+		Dst:      localIP6v1,
+		ProtoNum: 6,
+		L4Src:    nfnetlink.CtL4Src{Port: ip6SrcPort},
+		L4Dst:    nfnetlink.CtL4Dst{Port: ip6DstPort},
+	},
+	ReplyTuple: nfnetlink.CtTuple{
+		Src:      localIP6v1,
+		Dst:      remoteIP6v1,
+		ProtoNum: 6,
+		L4Src:    nfnetlink.CtL4Src{Port: ip6DstPort},
+		L4Dst:    nfnetlink.CtL4Dst{Port: ip6SrcPort},
+	},
+	OriginalCounters: nfnetlink.CtCounters{Packets: 1, Bytes: 100},
+	ReplyCounters:    nfnetlink.CtCounters{Packets: 2, Bytes: 250},
+	ProtoInfo:        nfnetlink.CtProtoInfo{State: nfnl.TCP_CONNTRACK_ESTABLISHED},
+}
+
+var outCtEntryIPv6 = nfnetlink.CtEntry{
+	OriginalTuple: nfnetlink.CtTuple{
+		Src:      localIP6v1,
+		Dst:      remoteIP6v1,
+		ProtoNum: 6,
+		L4Src:    nfnetlink.CtL4Src{Port: ip6SrcPort},
+		L4Dst:    nfnetlink.CtL4Dst{Port: ip6DstPort},
+	},
+	ReplyTuple: nfnetlink.CtTuple{
+		Src:      remoteIP6v1,
+		Dst:      localIP6v1,
+		ProtoNum: 6,
+		L4Src:    nfnetlink.CtL4Src{Port: ip6DstPort},
+		L4Dst:    nfnetlink.CtL4Dst{Port: ip6SrcPort},
+	},
+	OriginalCounters: nfnetlink.CtCounters{Packets: 1, Bytes: 100},
+	ReplyCounters:    nfnetlink.CtCounters{Packets: 2, Bytes: 250},
+	ProtoInfo:        nfnetlink.CtProtoInfo{State: nfnl.TCP_CONNTRACK_ESTABLISHED},
+}
+
+// inCtEntryWithDNATIPv6 is a NAT64/MAP-t-style DNAT: localIP6v1DNAT:ip6DstPortVIP (the VIP the
+// client dialed) is rewritten to localIP6v1:ip6DstPort.
+var inCtEntryWithDNATIPv6 = nfnetlink.CtEntry{
+	OriginalTuple: nfnetlink.CtTuple{
+		Src:      remoteIP6v1,
+		Dst:      localIP6v1DNAT,
+		ProtoNum: 6,
+		L4Src:    nfnetlink.CtL4Src{Port: ip6SrcPort},
+		L4Dst:    nfnetlink.CtL4Dst{Port: ip6DstPortVIP},
+	},
+	ReplyTuple: nfnetlink.CtTuple{
+		Src:      localIP6v1,
+		Dst:      remoteIP6v1,
+		ProtoNum: 6,
+		L4Src:    nfnetlink.CtL4Src{Port: ip6DstPort},
+		L4Dst:    nfnetlink.CtL4Dst{Port: ip6SrcPort},
+	},
+	Status:           nfnl.IPS_DST_NAT,
+	OriginalCounters: nfnetlink.CtCounters{Packets: 1, Bytes: 100},
+	ReplyCounters:    nfnetlink.CtCounters{Packets: 2, Bytes: 250},
+	ProtoInfo:        nfnetlink.CtProtoInfo{State: nfnl.TCP_CONNTRACK_ESTABLISHED},
+}
+
+var _ = Describe("IPv6 conntrack flows", func() {
+	Describe("Test local destination", func() {
+		It("converts counters and the 5-tuple without truncating the address", func() {
+			info := ConvertCtEntryToConntrackInfo(localCtEntryIPv6)
+
+			Expect(info.SrcIP.String()).To(Equal(remoteIP6v1.String()))
+			Expect(info.DstIP.String()).To(Equal(localIP6v1.String()))
+			Expect(info.SrcPort).To(Equal(ip6SrcPort))
+			Expect(info.DstPort).To(Equal(ip6DstPort))
+			Expect(info.OrigCounters.Packets).To(Equal(1))
+			Expect(info.ReplyCounters.Packets).To(Equal(2))
+			Expect(info.IsDNAT).To(BeFalse())
+		})
+	})
+
+	Describe("Test local source", func() {
+		It("converts counters and the 5-tuple for an outbound flow", func() {
+			info := ConvertCtEntryToConntrackInfo(outCtEntryIPv6)
+
+			Expect(info.SrcIP.String()).To(Equal(localIP6v1.String()))
+			Expect(info.DstIP.String()).To(Equal(remoteIP6v1.String()))
+			Expect(info.SrcPort).To(Equal(ip6SrcPort))
+			Expect(info.DstPort).To(Equal(ip6DstPort))
+		})
+	})
+
+	Describe("Test DNAT", func() {
+		It("reports the pre-DNAT VIP and port an AuditLogger record was enriched with", func() {
+			a := NewAuditLogger(calc.NewLookupsCache(), nil, 1.0, 0, 0)
+			info := ConvertCtEntryToConntrackInfo(inCtEntryWithDNATIPv6)
+			a.ctInfo = map[fiveTupleKey]clttypes.ConntrackInfo{
+				fiveTupleKeyFor(remoteIP6v1, localIP6v1, ip6SrcPort, ip6DstPort, 6): info,
+			}
+
+			agg := &nfnetlink.NflogPacketAggregate{
+				Tuple: nfnetlink.NflogPacketTuple{
+					Src:   ipTo16Byte(remoteIP6v1.String()),
+					Dst:   ipTo16Byte(localIP6v1.String()),
+					Proto: 6,
+					L4Src: nfnetlink.NflogL4Info{Port: ip6SrcPort},
+					L4Dst: nfnetlink.NflogL4Info{Port: ip6DstPort},
+				},
+				Prefixes: []nfnetlink.NflogPrefix{{Bytes: 100, Packets: 1}},
+			}
+			ruleID := &calc.RuleID{
+				PolicyID: calc.PolicyID{Tier: "default", Name: "allow-dnat-ipv6"},
+				IndexStr: "0",
+				Action:   rules.RuleActionAllow,
+			}
+
+			record := a.buildRecord(rules.RuleDirIngress, agg, agg.Prefixes[0], ruleID)
+
+			Expect(record.SrcIP).To(Equal(remoteIP6v1.String()))
+			Expect(record.DstIP).To(Equal(localIP6v1.String()))
+			Expect(record.IsDNAT).To(BeTrue())
+			Expect(record.PreDNATDstIP).To(Equal(localIP6v1DNAT.String()))
+			Expect(record.PreDNATDstPort).To(Equal(ip6DstPortVIP))
+		})
+	})
+})
@@ -0,0 +1,261 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !windows
+// +build !windows
+
+package collector
+
+import (
+	"bufio"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	clttypes "github.com/projectcalico/calico/felix/collector/types"
+	"github.com/projectcalico/calico/felix/nfnetlink/nfnl"
+)
+
+// ipvsConnFile and ipvsConnScanInterval are the /proc/net/ip_vs_conn table IPVSConntrackBackend
+// polls, and how often: IPVS has no netlink-style event stream of its own, so this mirrors the
+// polling BPFConntrackBackend does against its own map.
+const (
+	ipvsConnFile         = "/proc/net/ip_vs_conn"
+	ipvsConnScanInterval = 5 * time.Second
+)
+
+// IPVSConntrackBackend is the ConntrackBackend for kube-proxy IPVS-mode clusters: the NAT
+// mapping and connection state nf_conntrack would otherwise carry live in IPVS's own connection
+// table instead, since IPVS does DNAT in its own LVS code path rather than through netfilter
+// conntrack. It polls /proc/net/ip_vs_conn and translates each entry into the same
+// clttypes.ConntrackInfo shape NetlinkConntrackBackend produces, so the collector enriches flow
+// logs identically regardless of which kube-proxy mode a cluster runs.
+type IPVSConntrackBackend struct {
+	connFile string
+}
+
+// NewIPVSConntrackBackend returns a ConntrackBackend backed by the kernel's IPVS connection
+// table.
+func NewIPVSConntrackBackend() *IPVSConntrackBackend {
+	return &IPVSConntrackBackend{connFile: ipvsConnFile}
+}
+
+func (b *IPVSConntrackBackend) Dump() ([]clttypes.ConntrackInfo, error) {
+	f, err := os.Open(b.connFile)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return parseIPVSConnEntries(f)
+}
+
+// Subscribe has no IPVS event stream to follow, so like BPFConntrackBackend it polls on
+// ipvsConnScanInterval and emits every live entry on each pass.
+func (b *IPVSConntrackBackend) Subscribe(ctx context.Context) <-chan clttypes.ConntrackInfo {
+	out := make(chan clttypes.ConntrackInfo, 1000)
+	go func() {
+		defer close(out)
+		ticker := time.NewTicker(ipvsConnScanInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				infos, err := b.Dump()
+				if err != nil {
+					log.WithError(err).Warn("IPVSConntrackBackend: failed to scan ip_vs_conn")
+					continue
+				}
+				for _, info := range infos {
+					select {
+					case out <- info:
+					case <-ctx.Done():
+						return
+					}
+				}
+			}
+		}
+	}()
+	return out
+}
+
+// parseIPVSConnEntries parses the body of /proc/net/ip_vs_conn, skipping its header line.
+// Malformed rows are skipped with a warning rather than aborting the whole scan, since a single
+// bad row shouldn't hide every other live connection from the collector.
+func parseIPVSConnEntries(r *os.File) ([]clttypes.ConntrackInfo, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Scan() // header: "Pro FromIP FPort ToIP TPort DestIP DPort State Expires ..."
+
+	var infos []clttypes.ConntrackInfo
+	for scanner.Scan() {
+		fields := splitIPVSFields(scanner.Text())
+		if len(fields) < 8 {
+			continue
+		}
+		info, err := convertIPVSConnFields(fields)
+		if err != nil {
+			log.WithError(err).Warn("IPVSConntrackBackend: skipping unparsable ip_vs_conn row")
+			continue
+		}
+		infos = append(infos, info)
+	}
+	return infos, scanner.Err()
+}
+
+func splitIPVSFields(line string) []string {
+	var fields []string
+	start := -1
+	for i, c := range line {
+		if c == ' ' || c == '\t' {
+			if start >= 0 {
+				fields = append(fields, line[start:i])
+				start = -1
+			}
+			continue
+		}
+		if start < 0 {
+			start = i
+		}
+	}
+	if start >= 0 {
+		fields = append(fields, line[start:])
+	}
+	return fields
+}
+
+// convertIPVSConnFields turns one whitespace-split /proc/net/ip_vs_conn row -- proto, client
+// addr/port, VIP addr/port, real-server addr/port, state -- into a ConntrackInfo whose pre-DNAT
+// tuple is (client, VIP:vport) and whose SrcIP/DstIP/DstPort are the post-DNAT (client,
+// real:rport) tuple the workload endpoint actually sees, the same split NetlinkConntrackBackend
+// reports for an iptables DNAT via IPS_DST_NAT. ip_vs_conn carries no per-connection packet/byte
+// counters -- those are only aggregated per service/real-server in /proc/net/ip_vs -- so
+// OrigCounters/ReplyCounters are left zero.
+func convertIPVSConnFields(fields []string) (clttypes.ConntrackInfo, error) {
+	proto, err := ipvsProtoToNum(fields[0])
+	if err != nil {
+		return clttypes.ConntrackInfo{}, err
+	}
+	clientIP, err := parseIPVSHexIP(fields[1])
+	if err != nil {
+		return clttypes.ConntrackInfo{}, fmt.Errorf("FromIP: %w", err)
+	}
+	clientPort, err := parseIPVSHexPort(fields[2])
+	if err != nil {
+		return clttypes.ConntrackInfo{}, fmt.Errorf("FPort: %w", err)
+	}
+	vip, err := parseIPVSHexIP(fields[3])
+	if err != nil {
+		return clttypes.ConntrackInfo{}, fmt.Errorf("ToIP: %w", err)
+	}
+	vport, err := parseIPVSHexPort(fields[4])
+	if err != nil {
+		return clttypes.ConntrackInfo{}, fmt.Errorf("TPort: %w", err)
+	}
+	realIP, err := parseIPVSHexIP(fields[5])
+	if err != nil {
+		return clttypes.ConntrackInfo{}, fmt.Errorf("DestIP: %w", err)
+	}
+	realPort, err := parseIPVSHexPort(fields[6])
+	if err != nil {
+		return clttypes.ConntrackInfo{}, fmt.Errorf("DPort: %w", err)
+	}
+	state := fields[7]
+
+	info := clttypes.ConntrackInfo{
+		SrcIP:    clientIP,
+		DstIP:    realIP,
+		SrcPort:  clientPort,
+		DstPort:  realPort,
+		Protocol: proto,
+		Expired:  isClosedState(uint8(proto), ipvsStateToTCPConntrackState(state)),
+	}
+	if !realIP.Equal(vip) || realPort != vport {
+		info.IsDNAT = true
+		info.PreDNATAddr = vip
+		info.PreDNATPort = vport
+	}
+	return info, nil
+}
+
+func ipvsProtoToNum(proto string) (int, error) {
+	switch proto {
+	case "TCP":
+		return protoTCP, nil
+	case "UDP":
+		return protoUDP, nil
+	case "SCTP":
+		return protoSCTP, nil
+	default:
+		return 0, fmt.Errorf("unknown IPVS protocol %q", proto)
+	}
+}
+
+// parseIPVSHexIP decodes an ip_vs_conn address column: 8 hex digits for an IPv4 address, 32 for
+// an IPv6 one.
+func parseIPVSHexIP(s string) (net.IP, error) {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	switch len(b) {
+	case net.IPv4len, net.IPv6len:
+		return net.IP(b), nil
+	default:
+		return nil, fmt.Errorf("unexpected address length %d", len(b))
+	}
+}
+
+func parseIPVSHexPort(s string) (int, error) {
+	port, err := strconv.ParseUint(s, 16, 16)
+	if err != nil {
+		return 0, err
+	}
+	return int(port), nil
+}
+
+// ipvsStateToTCPConntrackState translates the connection state string IPVS reports in
+// /proc/net/ip_vs_conn into the same nfnl.TCP_CONNTRACK_* constants ConvertCtEntryToConntrackInfo
+// switches on, so isClosedState treats an IPVS connection's terminal states the same way it
+// treats an iptables/nf_conntrack one's. IPVS states with no netfilter TCP equivalent (e.g. its
+// UDP/ICMP placeholders) fall back to ESTABLISHED, since they never reach a state isClosedState
+// recognises as terminal anyway.
+func ipvsStateToTCPConntrackState(state string) uint8 {
+	switch state {
+	case "SYN_SENT":
+		return nfnl.TCP_CONNTRACK_SYN_SENT
+	case "SYN_RECV":
+		return nfnl.TCP_CONNTRACK_SYN_RECV
+	case "ESTABLISHED":
+		return nfnl.TCP_CONNTRACK_ESTABLISHED
+	case "FIN_WAIT":
+		return nfnl.TCP_CONNTRACK_FIN_WAIT
+	case "CLOSE_WAIT":
+		return nfnl.TCP_CONNTRACK_CLOSE_WAIT
+	case "LAST_ACK":
+		return nfnl.TCP_CONNTRACK_LAST_ACK
+	case "TIME_WAIT":
+		return nfnl.TCP_CONNTRACK_TIME_WAIT
+	case "CLOSE":
+		return nfnl.TCP_CONNTRACK_CLOSE
+	default:
+		return nfnl.TCP_CONNTRACK_ESTABLISHED
+	}
+}
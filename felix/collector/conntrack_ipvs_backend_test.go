@@ -0,0 +1,81 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !windows
+// +build !windows
+
+package collector
+
+import (
+	"os"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// writeIPVSFixture writes content to a temp file and returns it positioned at the start, since
+// parseIPVSConnEntries reads directly from the /proc/net/ip_vs_conn *os.File IPVSConntrackBackend
+// opens rather than from an io.Reader.
+func writeIPVSFixture(content string) *os.File {
+	f, err := os.CreateTemp("", "ip_vs_conn")
+	Expect(err).NotTo(HaveOccurred())
+	DeferCleanup(func() { os.Remove(f.Name()) })
+	_, err = f.WriteString(content)
+	Expect(err).NotTo(HaveOccurred())
+	_, err = f.Seek(0, 0)
+	Expect(err).NotTo(HaveOccurred())
+	return f
+}
+
+// These fixtures mirror the format of a real /proc/net/ip_vs_conn: a header line followed by
+// whitespace-separated hex-encoded rows of "Pro FromIP FPort ToIP TPort DestIP DPort State
+// Expires". 0A000001:C350 is the client, C0A80001:0050 the service VIP, 0A000002:1F90 the
+// backing pod IPVS DNAT'd the connection to.
+const ipvsConnTableFixture = `Pro FromIP   FPort ToIP     TPort DestIP   DPort State       Expires
+TCP 0A000001 C350  C0A80001 0050  0A000002 1F90  ESTABLISHED 899
+TCP 0A000001 C351  C0A80001 0050  0A000002 1F90  CLOSE       10
+UDP 0A000001 C352  C0A80001 0051  0A000003 1F91  ESTABLISHED 60
+`
+
+var _ = Describe("IPVS conntrack entries", func() {
+	It("splits a connection into the post-DNAT SrcIP/DstIP and the pre-DNAT VIP", func() {
+		infos, err := parseIPVSConnEntries(writeIPVSFixture(ipvsConnTableFixture))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(infos).To(HaveLen(3))
+
+		tcpEstablished := infos[0]
+		Expect(tcpEstablished.SrcIP.String()).To(Equal("10.0.0.1"))
+		Expect(tcpEstablished.SrcPort).To(Equal(0xC350))
+		Expect(tcpEstablished.DstIP.String()).To(Equal("10.0.0.2"))
+		Expect(tcpEstablished.DstPort).To(Equal(0x1F90))
+		Expect(tcpEstablished.Protocol).To(Equal(protoTCP))
+		Expect(tcpEstablished.IsDNAT).To(BeTrue())
+		Expect(tcpEstablished.PreDNATAddr.String()).To(Equal("192.168.0.1"))
+		Expect(tcpEstablished.PreDNATPort).To(Equal(0x0050))
+		Expect(tcpEstablished.Expired).To(BeFalse())
+	})
+
+	It("marks a CLOSE-state connection Expired the same way a closed iptables flow would be", func() {
+		infos, err := parseIPVSConnEntries(writeIPVSFixture(ipvsConnTableFixture))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(infos[1].Expired).To(BeTrue())
+	})
+
+	It("has no state machine for UDP, so it's never reported Expired by state alone", func() {
+		infos, err := parseIPVSConnEntries(writeIPVSFixture(ipvsConnTableFixture))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(infos[2].Protocol).To(Equal(protoUDP))
+		Expect(infos[2].Expired).To(BeFalse())
+	})
+})
@@ -0,0 +1,80 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !windows
+// +build !windows
+
+package collector
+
+import (
+	"context"
+	"sync"
+
+	clttypes "github.com/projectcalico/calico/felix/collector/types"
+)
+
+// MemConntrackBackend is a ConntrackBackend tests can drive directly, replacing the ad hoc
+// dummyConntrackInfoReader collector tests used to hand-roll: Feed pushes entries onto whatever
+// Subscribe channels are currently open, and Dump returns the entries seen so far.
+type MemConntrackBackend struct {
+	mu   sync.Mutex
+	dump []clttypes.ConntrackInfo
+	subs []chan clttypes.ConntrackInfo
+}
+
+// NewMemConntrackBackend returns an empty in-memory ConntrackBackend for tests.
+func NewMemConntrackBackend() *MemConntrackBackend {
+	return &MemConntrackBackend{}
+}
+
+// Feed delivers info to every open Subscribe channel and records it for future Dump calls.
+func (b *MemConntrackBackend) Feed(info clttypes.ConntrackInfo) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.dump = append(b.dump, info)
+	for _, sub := range b.subs {
+		sub <- info
+	}
+}
+
+func (b *MemConntrackBackend) Dump() ([]clttypes.ConntrackInfo, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	out := make([]clttypes.ConntrackInfo, len(b.dump))
+	copy(out, b.dump)
+	return out, nil
+}
+
+func (b *MemConntrackBackend) Subscribe(ctx context.Context) <-chan clttypes.ConntrackInfo {
+	out := make(chan clttypes.ConntrackInfo, 1000)
+
+	b.mu.Lock()
+	b.subs = append(b.subs, out)
+	b.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		for i, sub := range b.subs {
+			if sub == out {
+				b.subs = append(b.subs[:i], b.subs[i+1:]...)
+				break
+			}
+		}
+		close(out)
+	}()
+
+	return out
+}
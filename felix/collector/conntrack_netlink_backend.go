@@ -0,0 +1,168 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !windows
+// +build !windows
+
+package collector
+
+import (
+	"context"
+
+	log "github.com/sirupsen/logrus"
+
+	clttypes "github.com/projectcalico/calico/felix/collector/types"
+	"github.com/projectcalico/calico/felix/nfnetlink"
+	"github.com/projectcalico/calico/felix/nfnetlink/nfnl"
+)
+
+// NetlinkConntrackBackend is the ConntrackBackend for Felix's iptables/standard-kernel
+// dataplane: it reads the kernel's conntrack table over netlink via nfnetlink, the same
+// mechanism Felix has always used to enrich flow logs with NAT and counter information.
+type NetlinkConntrackBackend struct{}
+
+// NewNetlinkConntrackBackend returns a ConntrackBackend backed by the kernel conntrack table.
+func NewNetlinkConntrackBackend() *NetlinkConntrackBackend {
+	return &NetlinkConntrackBackend{}
+}
+
+func (b *NetlinkConntrackBackend) Dump() ([]clttypes.ConntrackInfo, error) {
+	entries, err := nfnetlink.ConntrackList()
+	if err != nil {
+		return nil, err
+	}
+	infos := make([]clttypes.ConntrackInfo, 0, len(entries))
+	for _, entry := range entries {
+		infos = append(infos, ConvertCtEntryToConntrackInfo(entry))
+	}
+	return infos, nil
+}
+
+func (b *NetlinkConntrackBackend) Subscribe(ctx context.Context) <-chan clttypes.ConntrackInfo {
+	out := make(chan clttypes.ConntrackInfo, 1000)
+	updates, err := nfnetlink.ConntrackUpdates(ctx)
+	if err != nil {
+		log.WithError(err).Error("NetlinkConntrackBackend: failed to subscribe to conntrack updates")
+		close(out)
+		return out
+	}
+	go func() {
+		defer close(out)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case entry, ok := <-updates:
+				if !ok {
+					return
+				}
+				out <- ConvertCtEntryToConntrackInfo(entry)
+			}
+		}
+	}()
+	return out
+}
+
+// Protocol numbers ConvertCtEntryToConntrackInfo gives protocol-specific treatment to; every
+// other protocol number is treated like TCP/UDP (a plain, port-keyed flow).
+const (
+	protoTCP    = 6
+	protoUDP    = 17
+	protoICMP   = 1
+	protoICMPv6 = 58
+	protoSCTP   = 132
+)
+
+// isICMP reports whether protoNum is ICMP or ICMPv6, the two protocols that identify a flow by
+// echo id/type/code rather than by source/destination port.
+func isICMP(protoNum uint8) bool {
+	return protoNum == protoICMP || protoNum == protoICMPv6
+}
+
+// icmpIdentifiers packs an ICMP/ICMPv6 echo's id, type, and code into the SrcPort/DstPort slots
+// ConntrackInfo otherwise uses for a flow's source and destination ports, so fiveTupleKeyFor and
+// every cache keyed on a ConntrackInfo's 5-tuple work for ICMP without an ICMP-specific code
+// path. The echo id goes in SrcPort unchanged, since it's what ties a request to its reply across
+// both directions; type and code are packed into DstPort as (type<<8 | code), because a request
+// and its reply always carry the same id and code but swap type (8<->0 for ICMP echo, 128<->129
+// for ICMPv6 echo), so DstPort still differs between the two directions the way a real port pair
+// would.
+func icmpIdentifiers(tuple nfnetlink.CtTuple) (srcPort, dstPort int) {
+	return tuple.ICMPID, int(tuple.ICMPType)<<8 | int(tuple.ICMPCode)
+}
+
+// isClosedState reports whether entry's ProtoInfo.State indicates the flow is winding down.
+// Only TCP and SCTP carry a protocol state machine in conntrack; UDP and ICMP flows have no
+// equivalent, so they're never marked Expired here and instead rely entirely on the DESTROY event
+// path (see dedupeConntrackEvents) to signal that the flow has ended.
+func isClosedState(protoNum uint8, state uint8) bool {
+	switch protoNum {
+	case protoTCP:
+		return state == nfnl.TCP_CONNTRACK_CLOSE || state == nfnl.TCP_CONNTRACK_TIME_WAIT
+	case protoSCTP:
+		return state == nfnl.SCTP_CONNTRACK_CLOSED
+	default:
+		return false
+	}
+}
+
+// ConvertCtEntryToConntrackInfo translates a raw netlink conntrack entry into the
+// dataplane-agnostic ConntrackInfo shape the collector works with, deriving the DNAT/SNAT flags
+// and pre-translation addresses from the kernel's IPS_SRC_NAT/IPS_DST_NAT status bits the same
+// way outCtEntryWithSNAT/inCtEntryWithDNAT are constructed in tests.
+func ConvertCtEntryToConntrackInfo(entry nfnetlink.CtEntry) clttypes.ConntrackInfo {
+	srcPort, dstPort := entry.OriginalTuple.L4Src.Port, entry.OriginalTuple.L4Dst.Port
+	if isICMP(entry.OriginalTuple.ProtoNum) {
+		srcPort, dstPort = icmpIdentifiers(entry.OriginalTuple)
+	}
+
+	info := clttypes.ConntrackInfo{
+		Expired:  isClosedState(entry.OriginalTuple.ProtoNum, entry.ProtoInfo.State),
+		Zone:     entry.Zone,
+		SrcIP:    entry.OriginalTuple.Src,
+		DstIP:    entry.OriginalTuple.Dst,
+		SrcPort:  srcPort,
+		DstPort:  dstPort,
+		Protocol: int(entry.OriginalTuple.ProtoNum),
+		OrigCounters: clttypes.Counters{
+			Packets: entry.OriginalCounters.Packets,
+			Bytes:   entry.OriginalCounters.Bytes,
+		},
+		ReplyCounters: clttypes.Counters{
+			Packets: entry.ReplyCounters.Packets,
+			Bytes:   entry.ReplyCounters.Bytes,
+		},
+	}
+
+	if entry.Status&nfnl.IPS_DST_NAT != 0 {
+		info.IsDNAT = true
+		info.PreDNATAddr = entry.OriginalTuple.Dst
+		info.PreDNATPort = dstPort
+		if isICMP(entry.OriginalTuple.ProtoNum) {
+			// DNAT can rewrite an ICMP echo's id but never its type/code, so report the pre-DNAT id.
+			info.PreDNATPort = srcPort
+		}
+	}
+	if entry.Status&nfnl.IPS_SRC_NAT != 0 {
+		info.IsSNAT = true
+		info.PostSNATAddr = entry.ReplyTuple.Dst
+		postSNATPort := entry.ReplyTuple.L4Dst.Port
+		if isICMP(entry.OriginalTuple.ProtoNum) {
+			_, postSNATPort = icmpIdentifiers(entry.ReplyTuple)
+		}
+		info.PostSNATPort = postSNATPort
+	}
+
+	return info
+}
@@ -0,0 +1,231 @@
+//go:build !windows
+// +build !windows
+
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"net"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/projectcalico/calico/felix/calc"
+	clttypes "github.com/projectcalico/calico/felix/collector/types"
+	"github.com/projectcalico/calico/felix/nfnetlink"
+	"github.com/projectcalico/calico/felix/nfnetlink/nfnl"
+	"github.com/projectcalico/calico/felix/rules"
+)
+
+// These fixtures mirror the IPv4 "local destination"/"DNAT" scenarios in collector_test.go and
+// conntrack_ipv6_test.go, but for the two protocol families ConvertCtEntryToConntrackInfo didn't
+// previously special-case: SCTP (port-keyed like TCP/UDP, but with its own ProtoInfo.State
+// machine) and ICMP (no ports at all -- keyed on echo id/type/code via icmpIdentifiers).
+var (
+	sctpLocalIP  = net.ParseIP("10.0.1.1")
+	sctpRemoteIP = net.ParseIP("10.0.1.2")
+
+	icmpLocalIP     = net.ParseIP("10.0.2.1")
+	icmpRemoteIP    = net.ParseIP("10.0.2.2")
+	icmpLocalIPDNAT = net.ParseIP("10.0.2.254")
+)
+
+const (
+	sctpSrcPort = 31000
+	sctpDstPort = 3868
+
+	// icmpEchoID is the echo identifier the kernel uses to match a ping's request and reply;
+	// icmpEchoIDDNAT stands in for the case where DNAT'ing an ICMP echo also rewrites its id,
+	// the ICMP analogue of a DNAT rewriting a TCP/UDP source port.
+	icmpEchoID     = 4242
+	icmpEchoIDDNAT = 7777
+)
+
+var localCtEntrySCTP = nfnetlink.CtEntry{
+	OriginalTuple: nfnetlink.CtTuple{
+		Src:      sctpRemoteIP,
+		Dst:      sctpLocalIP,
+		ProtoNum: protoSCTP,
+		L4Src:    nfnetlink.CtL4Src{Port: sctpSrcPort},
+		L4Dst:    nfnetlink.CtL4Dst{Port: sctpDstPort},
+	},
+	ReplyTuple: nfnetlink.CtTuple{
+		Src:      sctpLocalIP,
+		Dst:      sctpRemoteIP,
+		ProtoNum: protoSCTP,
+		L4Src:    nfnetlink.CtL4Src{Port: sctpDstPort},
+		L4Dst:    nfnetlink.CtL4Dst{Port: sctpSrcPort},
+	},
+	OriginalCounters: nfnetlink.CtCounters{Packets: 1, Bytes: 100},
+	ReplyCounters:    nfnetlink.CtCounters{Packets: 2, Bytes: 250},
+	ProtoInfo:        nfnetlink.CtProtoInfo{State: nfnl.SCTP_CONNTRACK_ESTABLISHED},
+}
+
+var closedCtEntrySCTP = nfnetlink.CtEntry{
+	OriginalTuple: nfnetlink.CtTuple{
+		Src:      sctpRemoteIP,
+		Dst:      sctpLocalIP,
+		ProtoNum: protoSCTP,
+		L4Src:    nfnetlink.CtL4Src{Port: sctpSrcPort},
+		L4Dst:    nfnetlink.CtL4Dst{Port: sctpDstPort},
+	},
+	ReplyTuple: nfnetlink.CtTuple{
+		Src:      sctpLocalIP,
+		Dst:      sctpRemoteIP,
+		ProtoNum: protoSCTP,
+		L4Src:    nfnetlink.CtL4Src{Port: sctpDstPort},
+		L4Dst:    nfnetlink.CtL4Dst{Port: sctpSrcPort},
+	},
+	OriginalCounters: nfnetlink.CtCounters{Packets: 1, Bytes: 100},
+	ReplyCounters:    nfnetlink.CtCounters{Packets: 2, Bytes: 250},
+	ProtoInfo:        nfnetlink.CtProtoInfo{State: nfnl.SCTP_CONNTRACK_CLOSED},
+}
+
+// localCtEntryICMP is an inbound echo request/reply pair: ICMPType 8 is an echo request,
+// ICMPType 0 is its reply, and both directions share the same id and code.
+var localCtEntryICMP = nfnetlink.CtEntry{
+	OriginalTuple: nfnetlink.CtTuple{
+		Src:      icmpRemoteIP,
+		Dst:      icmpLocalIP,
+		ProtoNum: protoICMP,
+		ICMPType: 8,
+		ICMPCode: 0,
+		ICMPID:   icmpEchoID,
+	},
+	ReplyTuple: nfnetlink.CtTuple{
+		Src:      icmpLocalIP,
+		Dst:      icmpRemoteIP,
+		ProtoNum: protoICMP,
+		ICMPType: 0,
+		ICMPCode: 0,
+		ICMPID:   icmpEchoID,
+	},
+	OriginalCounters: nfnetlink.CtCounters{Packets: 1, Bytes: 84},
+	ReplyCounters:    nfnetlink.CtCounters{Packets: 1, Bytes: 84},
+}
+
+// inCtEntryWithDNATICMP DNATs an inbound echo request from icmpLocalIPDNAT (the VIP the remote
+// host pinged) to icmpLocalIP, and rewrites the echo id along the way -- the ICMP analogue of a
+// DNAT rewriting a TCP/UDP destination port.
+var inCtEntryWithDNATICMP = nfnetlink.CtEntry{
+	OriginalTuple: nfnetlink.CtTuple{
+		Src:      icmpRemoteIP,
+		Dst:      icmpLocalIPDNAT,
+		ProtoNum: protoICMP,
+		ICMPType: 8,
+		ICMPCode: 0,
+		ICMPID:   icmpEchoIDDNAT,
+	},
+	ReplyTuple: nfnetlink.CtTuple{
+		Src:      icmpLocalIP,
+		Dst:      icmpRemoteIP,
+		ProtoNum: protoICMP,
+		ICMPType: 0,
+		ICMPCode: 0,
+		ICMPID:   icmpEchoID,
+	},
+	Status:           nfnl.IPS_DST_NAT,
+	OriginalCounters: nfnetlink.CtCounters{Packets: 1, Bytes: 84},
+	ReplyCounters:    nfnetlink.CtCounters{Packets: 1, Bytes: 84},
+}
+
+var _ = Describe("SCTP conntrack flows", func() {
+	Describe("Test local destination", func() {
+		It("converts counters and the 5-tuple like any other port-keyed protocol", func() {
+			info := ConvertCtEntryToConntrackInfo(localCtEntrySCTP)
+
+			Expect(info.SrcIP.String()).To(Equal(sctpRemoteIP.String()))
+			Expect(info.DstIP.String()).To(Equal(sctpLocalIP.String()))
+			Expect(info.SrcPort).To(Equal(sctpSrcPort))
+			Expect(info.DstPort).To(Equal(sctpDstPort))
+			Expect(info.Protocol).To(Equal(protoSCTP))
+			Expect(info.Expired).To(BeFalse())
+		})
+	})
+
+	Describe("Test association shutdown", func() {
+		It("marks the flow Expired once ProtoInfo.State reaches SCTP_CONNTRACK_CLOSED", func() {
+			info := ConvertCtEntryToConntrackInfo(closedCtEntrySCTP)
+			Expect(info.Expired).To(BeTrue())
+		})
+	})
+})
+
+var _ = Describe("ICMP conntrack flows", func() {
+	Describe("Test local destination", func() {
+		It("keys the flow on echo id/type/code instead of a port pair", func() {
+			info := ConvertCtEntryToConntrackInfo(localCtEntryICMP)
+
+			Expect(info.SrcIP.String()).To(Equal(icmpRemoteIP.String()))
+			Expect(info.DstIP.String()).To(Equal(icmpLocalIP.String()))
+			Expect(info.SrcPort).To(Equal(icmpEchoID))
+			Expect(info.DstPort).To(Equal(8 << 8))
+			Expect(info.Protocol).To(Equal(protoICMP))
+		})
+
+		It("gives the reply direction a DstPort that still differs from the request's", func() {
+			reqInfo := ConvertCtEntryToConntrackInfo(localCtEntryICMP)
+			replyInfo := ConvertCtEntryToConntrackInfo(nfnetlink.CtEntry{
+				OriginalTuple:    localCtEntryICMP.ReplyTuple,
+				ReplyTuple:       localCtEntryICMP.OriginalTuple,
+				OriginalCounters: localCtEntryICMP.ReplyCounters,
+				ReplyCounters:    localCtEntryICMP.OriginalCounters,
+			})
+
+			// Same echo id ties the two directions together; different encoded type keeps their
+			// 5-tuple keys distinct, just as a request/reply port pair would.
+			Expect(replyInfo.SrcPort).To(Equal(reqInfo.SrcPort))
+			Expect(replyInfo.DstPort).NotTo(Equal(reqInfo.DstPort))
+		})
+	})
+
+	Describe("Test DNAT", func() {
+		It("reports the pre-DNAT VIP, and the pre-DNAT echo id, an AuditLogger record was enriched with", func() {
+			a := NewAuditLogger(calc.NewLookupsCache(), nil, 1.0, 0, 0)
+			info := ConvertCtEntryToConntrackInfo(inCtEntryWithDNATICMP)
+			a.ctInfo = map[fiveTupleKey]clttypes.ConntrackInfo{
+				fiveTupleKeyFor(icmpRemoteIP, icmpLocalIP, icmpEchoID, 8<<8, protoICMP): info,
+			}
+
+			// The NFLOG decode path is expected to populate L4Src/L4Dst the same way
+			// ConvertCtEntryToConntrackInfo does for conntrack entries: echo id in the source
+			// slot, (type<<8 | code) in the destination slot.
+			agg := &nfnetlink.NflogPacketAggregate{
+				Tuple: nfnetlink.NflogPacketTuple{
+					Src:   ipTo16Byte(icmpRemoteIP.String()),
+					Dst:   ipTo16Byte(icmpLocalIP.String()),
+					Proto: protoICMP,
+					L4Src: nfnetlink.NflogL4Info{Port: icmpEchoID},
+					L4Dst: nfnetlink.NflogL4Info{Port: 8 << 8},
+				},
+				Prefixes: []nfnetlink.NflogPrefix{{Bytes: 84, Packets: 1}},
+			}
+			ruleID := &calc.RuleID{
+				PolicyID: calc.PolicyID{Tier: "default", Name: "allow-dnat-icmp"},
+				IndexStr: "0",
+				Action:   rules.RuleActionAllow,
+			}
+
+			record := a.buildRecord(rules.RuleDirIngress, agg, agg.Prefixes[0], ruleID)
+
+			Expect(record.SrcIP).To(Equal(icmpRemoteIP.String()))
+			Expect(record.DstIP).To(Equal(icmpLocalIP.String()))
+			Expect(record.IsDNAT).To(BeTrue())
+			Expect(record.PreDNATDstIP).To(Equal(icmpLocalIPDNAT.String()))
+			Expect(record.PreDNATDstPort).To(Equal(icmpEchoIDDNAT))
+		})
+	})
+})
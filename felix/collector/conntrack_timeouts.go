@@ -0,0 +1,103 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !windows
+// +build !windows
+
+package collector
+
+import (
+	"time"
+
+	"github.com/projectcalico/calico/felix/nfnetlink/nfnl"
+)
+
+// ConntrackTimeouts is a per-protocol-state timeout table: Expired reports whether a flow has
+// been sitting in a given state longer than that state's timeout, without needing the terminal
+// update (TCP_CONNTRACK_CLOSE/TIME_WAIT, SCTP_CONNTRACK_CLOSED) the collector otherwise relies on
+// to know a flow has ended. Config has no field for it and the collector does not use it: no
+// Data is stamped with a state or last-seen time, and no sweep of c.epStats calls Expired.
+type ConntrackTimeouts struct {
+	SynSent     time.Duration
+	SynRecv     time.Duration
+	Established time.Duration
+	FinWait     time.Duration
+	CloseWait   time.Duration
+	LastAck     time.Duration
+	TimeWait    time.Duration
+	Close       time.Duration
+
+	// Unreplied is used for protocols with no conntrack state machine (UDP, ICMP) and for any
+	// TCP/SCTP state this table doesn't otherwise distinguish, mirroring nf_conntrack's own
+	// "unreplied" bucket.
+	Unreplied time.Duration
+}
+
+// DefaultConntrackTimeouts returns timeouts that roughly match the stock Linux
+// net.netfilter.nf_conntrack_tcp_timeout_* sysctls, so a flow the sweep evicts ages out on about
+// the same schedule the kernel's own conntrack garbage collector would have used for it.
+func DefaultConntrackTimeouts() ConntrackTimeouts {
+	return ConntrackTimeouts{
+		SynSent:     120 * time.Second,
+		SynRecv:     60 * time.Second,
+		Established: 5 * 24 * time.Hour,
+		FinWait:     120 * time.Second,
+		CloseWait:   60 * time.Second,
+		LastAck:     30 * time.Second,
+		TimeWait:    120 * time.Second,
+		Close:       10 * time.Second,
+		Unreplied:   30 * time.Second,
+	}
+}
+
+// forState returns the timeout t assigns to a conntrack entry in protoState for the given
+// protocol number, falling back to Unreplied for protocols (UDP, ICMP) and states this table
+// doesn't give their own bucket.
+func (t ConntrackTimeouts) forState(protocol int, protoState uint8) time.Duration {
+	switch protocol {
+	case protoTCP:
+		switch protoState {
+		case nfnl.TCP_CONNTRACK_SYN_SENT:
+			return t.SynSent
+		case nfnl.TCP_CONNTRACK_SYN_RECV:
+			return t.SynRecv
+		case nfnl.TCP_CONNTRACK_ESTABLISHED:
+			return t.Established
+		case nfnl.TCP_CONNTRACK_FIN_WAIT:
+			return t.FinWait
+		case nfnl.TCP_CONNTRACK_CLOSE_WAIT:
+			return t.CloseWait
+		case nfnl.TCP_CONNTRACK_LAST_ACK:
+			return t.LastAck
+		case nfnl.TCP_CONNTRACK_TIME_WAIT:
+			return t.TimeWait
+		case nfnl.TCP_CONNTRACK_CLOSE:
+			return t.Close
+		}
+	case protoSCTP:
+		switch protoState {
+		case nfnl.SCTP_CONNTRACK_ESTABLISHED:
+			return t.Established
+		case nfnl.SCTP_CONNTRACK_CLOSED:
+			return t.Close
+		}
+	}
+	return t.Unreplied
+}
+
+// Expired reports whether a flow last seen at lastSeen, in protoState, has outlived that state's
+// timeout as of now, independent of whether a terminal netlink event ever arrived for the tuple.
+func (t ConntrackTimeouts) Expired(protocol int, protoState uint8, lastSeen, now time.Time) bool {
+	return now.Sub(lastSeen) > t.forState(protocol, protoState)
+}
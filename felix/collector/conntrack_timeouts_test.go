@@ -0,0 +1,71 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !windows
+// +build !windows
+
+package collector
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/projectcalico/calico/felix/nfnetlink/nfnl"
+)
+
+var _ = Describe("ConntrackTimeouts", func() {
+	timeouts := DefaultConntrackTimeouts()
+
+	DescribeTable("resolves the timeout for a protocol/state pair",
+		func(protocol int, state uint8, expected time.Duration) {
+			Expect(timeouts.forState(protocol, state)).To(Equal(expected))
+		},
+		Entry("TCP SYN_SENT", protoTCP, nfnl.TCP_CONNTRACK_SYN_SENT, timeouts.SynSent),
+		Entry("TCP ESTABLISHED", protoTCP, nfnl.TCP_CONNTRACK_ESTABLISHED, timeouts.Established),
+		Entry("TCP CLOSE_WAIT", protoTCP, nfnl.TCP_CONNTRACK_CLOSE_WAIT, timeouts.CloseWait),
+		Entry("TCP TIME_WAIT", protoTCP, nfnl.TCP_CONNTRACK_TIME_WAIT, timeouts.TimeWait),
+		Entry("TCP CLOSE", protoTCP, nfnl.TCP_CONNTRACK_CLOSE, timeouts.Close),
+		Entry("SCTP ESTABLISHED", protoSCTP, nfnl.SCTP_CONNTRACK_ESTABLISHED, timeouts.Established),
+		Entry("SCTP CLOSED", protoSCTP, nfnl.SCTP_CONNTRACK_CLOSED, timeouts.Close),
+		Entry("UDP has no state machine", protoUDP, uint8(0), timeouts.Unreplied),
+		Entry("ICMP has no state machine", protoICMP, uint8(0), timeouts.Unreplied),
+	)
+
+	Describe("Expired", func() {
+		It("fires once a flow has sat in its state longer than the state's timeout, with no terminal event", func() {
+			now := time.Now()
+			lastSeen := now.Add(-(timeouts.CloseWait + time.Second))
+
+			// CLOSE_WAIT, not CLOSE or TIME_WAIT: this flow never reached a terminal state,
+			// it simply sat in CLOSE_WAIT past that state's own timeout.
+			Expect(timeouts.Expired(protoTCP, nfnl.TCP_CONNTRACK_CLOSE_WAIT, lastSeen, now)).To(BeTrue())
+		})
+
+		It("does not fire while a flow is still within its state's timeout", func() {
+			now := time.Now()
+			lastSeen := now.Add(-(timeouts.CloseWait / 2))
+
+			Expect(timeouts.Expired(protoTCP, nfnl.TCP_CONNTRACK_CLOSE_WAIT, lastSeen, now)).To(BeFalse())
+		})
+
+		It("uses the long ESTABLISHED timeout so a healthy long-lived flow is never swept", func() {
+			now := time.Now()
+			lastSeen := now.Add(-time.Hour)
+
+			Expect(timeouts.Expired(protoTCP, nfnl.TCP_CONNTRACK_ESTABLISHED, lastSeen, now)).To(BeFalse())
+		})
+	})
+})
@@ -0,0 +1,202 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !windows
+// +build !windows
+
+package collector
+
+import (
+	"context"
+	"net"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/projectcalico/calico/felix/calc"
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/model"
+)
+
+// minDNSResolveInterval bounds how often we'll re-resolve a name when either the upstream
+// answer didn't carry a usable TTL, or resolution just failed and we're backing off.
+const minDNSResolveInterval = 30 * time.Second
+
+// DNSResolver looks up the current IPs for a DNS name. NewNetDNSResolver wraps net.Resolver for
+// production use; tests substitute a fake that returns canned answers without touching the
+// network.
+type DNSResolver interface {
+	Resolve(ctx context.Context, name string) (ips []net.IP, ttl time.Duration, err error)
+}
+
+// netDNSResolver is the net.Resolver-backed DNSResolver used in production.
+type netDNSResolver struct {
+	resolver *net.Resolver
+}
+
+// NewNetDNSResolver returns a DNSResolver backed by net.DefaultResolver.
+func NewNetDNSResolver() DNSResolver {
+	return &netDNSResolver{resolver: net.DefaultResolver}
+}
+
+func (r *netDNSResolver) Resolve(ctx context.Context, name string) ([]net.IP, time.Duration, error) {
+	ips, err := r.resolver.LookupIP(ctx, "ip", name)
+	if err != nil {
+		return nil, 0, err
+	}
+	// Go's net.Resolver doesn't expose the answer's own TTL, so we fall back to a fixed
+	// minimum and rely on re-resolution to pick up record changes reasonably promptly anyway.
+	return ips, minDNSResolveInterval, nil
+}
+
+// DNSNetworkSetResolver periodically resolves the FQDNs configured on DNS-based NetworkSets
+// (model.NetworkSet's DNSNames field) and keeps the resulting IPs inserted into a LookupsCache
+// for the duration of each answer's TTL, evicting them on expiry or once the name stops
+// resolving to that IP. This is what lets NFLOG/conntrack-derived flows to those IPs attribute
+// to the DNS-labelled netset in flow logs and audit records, instead of showing up as "unknown".
+type DNSNetworkSetResolver struct {
+	lookups  *calc.LookupsCache
+	resolver DNSResolver
+
+	mu      sync.Mutex
+	entries map[dnsResolveKey]*dnsResolveEntry
+	wg      sync.WaitGroup
+}
+
+type dnsResolveKey struct {
+	netSet model.NetworkSetKey
+	name   string
+}
+
+type dnsResolveEntry struct {
+	ips    []net.IP
+	cancel context.CancelFunc
+}
+
+// NewDNSNetworkSetResolver builds a DNSNetworkSetResolver that inserts resolved IPs into
+// lookups, using resolver to answer DNS queries.
+func NewDNSNetworkSetResolver(lookups *calc.LookupsCache, resolver DNSResolver) *DNSNetworkSetResolver {
+	return &DNSNetworkSetResolver{
+		lookups:  lookups,
+		resolver: resolver,
+		entries:  map[dnsResolveKey]*dnsResolveEntry{},
+	}
+}
+
+// Watch starts (or restarts) resolution of names for netSet, replacing whatever set of names
+// was previously registered for it. Calling this with an empty names slice stops resolving and
+// evicts any IPs currently attributed to netSet. The calc graph does not call it; a caller passes
+// the names for each NetworkSet/GlobalNetworkSet itself.
+func (d *DNSNetworkSetResolver) Watch(ctx context.Context, netSet model.NetworkSetKey, names []string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	wanted := map[string]bool{}
+	for _, name := range names {
+		wanted[name] = true
+	}
+	for key, entry := range d.entries {
+		if key.netSet != netSet || wanted[key.name] {
+			continue
+		}
+		entry.cancel()
+		d.evictLocked(entry)
+		delete(d.entries, key)
+	}
+
+	for _, name := range names {
+		key := dnsResolveKey{netSet: netSet, name: name}
+		if _, exists := d.entries[key]; exists {
+			continue
+		}
+		entryCtx, cancel := context.WithCancel(ctx)
+		entry := &dnsResolveEntry{cancel: cancel}
+		d.entries[key] = entry
+		d.wg.Add(1)
+		go d.resolveLoop(entryCtx, key, entry)
+	}
+}
+
+func (d *DNSNetworkSetResolver) resolveLoop(ctx context.Context, key dnsResolveKey, entry *dnsResolveEntry) {
+	defer d.wg.Done()
+	for {
+		ips, ttl, err := d.resolver.Resolve(ctx, key.name)
+		if err != nil {
+			log.WithError(err).WithField("name", key.name).Warn(
+				"DNSNetworkSetResolver: resolution failed; retrying after the minimum interval")
+			ttl = minDNSResolveInterval
+		} else {
+			d.refresh(key, entry, ips, ttl)
+			if ttl <= 0 {
+				ttl = minDNSResolveInterval
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(ttl):
+		}
+	}
+}
+
+func (d *DNSNetworkSetResolver) refresh(key dnsResolveKey, entry *dnsResolveEntry, ips []net.IP, ttl time.Duration) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	stale := entry.ips
+	entry.ips = ips
+
+	for _, ip := range stale {
+		if !containsIP(ips, ip) {
+			d.lookups.RemoveDynamicNetworkSetIP(ipTo16Byte(ip))
+		}
+	}
+	for _, ip := range ips {
+		d.lookups.SetDynamicNetworkSetIP(ipTo16Byte(ip), key.netSet, ttl)
+	}
+}
+
+// evictLocked must be called with d.mu held.
+func (d *DNSNetworkSetResolver) evictLocked(entry *dnsResolveEntry) {
+	for _, ip := range entry.ips {
+		d.lookups.RemoveDynamicNetworkSetIP(ipTo16Byte(ip))
+	}
+}
+
+// Stop cancels every in-flight resolution loop and waits for them to exit. It does not evict
+// already-resolved IPs, since Stop is expected to precede process shutdown, not a live
+// reconfiguration — use Watch with an empty names slice for that.
+func (d *DNSNetworkSetResolver) Stop() {
+	d.mu.Lock()
+	for _, entry := range d.entries {
+		entry.cancel()
+	}
+	d.mu.Unlock()
+	d.wg.Wait()
+}
+
+func containsIP(ips []net.IP, target net.IP) bool {
+	for _, ip := range ips {
+		if ip.Equal(target) {
+			return true
+		}
+	}
+	return false
+}
+
+func ipTo16Byte(ip net.IP) [16]byte {
+	var out [16]byte
+	copy(out[:], ip.To16())
+	return out
+}
@@ -0,0 +1,138 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !windows
+// +build !windows
+
+package collector
+
+import (
+	"math/rand"
+	"sync"
+
+	"github.com/projectcalico/calico/felix/collector/types/tuple"
+)
+
+// FlowReservoirSampler bounds the number of concurrently tracked flows in c.epStats to
+// Config.MaxTrackedFlows using Algorithm R reservoir sampling, so a flow storm (far more distinct
+// tuples than MaxTrackedFlows) makes the collector keep a capacity-sized random sample of flows
+// rather than either growing epStats unboundedly or deterministically dropping whatever arrives
+// after the cap, which would bias the survivors towards whichever flows happened to show up
+// first. It is only ever consulted for a tuple not already in epStats -- an update for a tuple
+// already resident always goes through regardless of sampling, as required by the n-th new tuple
+// rule Admit implements below.
+type FlowReservoirSampler struct {
+	capacity int
+
+	mu    sync.Mutex
+	seen  int
+	slots []tuple.Tuple
+	// occupied tracks which slots currently hold a live tuple, so Forget can tombstone a slot
+	// (leave it empty) instead of shrinking slots -- shrinking would make len(slots) dip below
+	// capacity again and reopen the "always admit" branch of Admit for the next tuple, which
+	// breaks Algorithm R's invariant that every tuple seen after the first capacity of them
+	// has only a capacity/seen chance of being retained.
+	occupied []bool
+	entries  map[tuple.Tuple]int
+}
+
+// NewFlowReservoirSampler returns a sampler that admits at most capacity new tuples concurrently.
+func NewFlowReservoirSampler(capacity int) *FlowReservoirSampler {
+	return &FlowReservoirSampler{
+		capacity: capacity,
+		entries:  make(map[tuple.Tuple]int, capacity),
+		slots:    make([]tuple.Tuple, 0, capacity),
+		occupied: make([]bool, 0, capacity),
+	}
+}
+
+// Admit runs one step of Algorithm R for a brand-new tuple t: while fewer than capacity tuples
+// have ever been offered, it always admits, growing slots. Once s.seen exceeds capacity, every
+// further tuple draws j uniformly from [0, n) where n is the count of new tuples offered so far
+// (including t); if j lands on an occupied slot, that slot's tuple is evicted and t takes its
+// place, if j lands on a slot a prior Forget emptied, t just takes the empty slot with nothing to
+// evict, otherwise t itself is rejected. This is driven off s.seen rather than len(slots), so a
+// Forget that empties a slot below capacity can't reopen the "always admit" phase. The caller
+// must evict the returned tuple from epStats (via deleteDataFromEpStats) whenever evicted is
+// true, and must skip admitting t into epStats whenever admitted is false.
+func (s *FlowReservoirSampler) Admit(t tuple.Tuple) (victim tuple.Tuple, evicted bool, admitted bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.seen++
+	if s.seen <= s.capacity {
+		idx := len(s.slots)
+		s.slots = append(s.slots, t)
+		s.occupied = append(s.occupied, true)
+		s.entries[t] = idx
+		return tuple.Tuple{}, false, true
+	}
+
+	j := rand.Intn(s.seen)
+	if j >= s.capacity {
+		return tuple.Tuple{}, false, false
+	}
+
+	if s.occupied[j] {
+		victim = s.slots[j]
+		delete(s.entries, victim)
+		evicted = true
+	}
+	s.slots[j] = t
+	s.occupied[j] = true
+	s.entries[t] = j
+	return victim, evicted, true
+}
+
+// Forget drops t from the reservoir's bookkeeping, for a sampled tuple that ages out through the
+// collector's normal expiry path rather than being displaced by Admit. It tombstones t's slot
+// (marking it unoccupied) rather than compacting slots, so a subsequent Admit still treats every
+// tuple since the reservoir first filled as subject to the capacity/seen draw, not as a fresh
+// always-admit below capacity. It does not affect future sampling odds -- s.seen only ever grows,
+// matching Algorithm R's definition of n.
+func (s *FlowReservoirSampler) Forget(t tuple.Tuple) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	idx, ok := s.entries[t]
+	if !ok {
+		return
+	}
+	delete(s.entries, t)
+	s.slots[idx] = tuple.Tuple{}
+	s.occupied[idx] = false
+}
+
+// SampleWeight returns 1/p, the factor the collector stamps onto every exported flow log/metric
+// update as sample_weight so downstream aggregators can un-bias packet/byte counts. Algorithm R's
+// invariant is that once s.seen new tuples have been offered to a reservoir of size capacity,
+// every tuple currently resident was admitted with the same probability capacity/s.seen -- so a
+// single weight applies to every flow while the sampler is active, and it's exactly 1 (no bias)
+// until the reservoir actually fills.
+func (s *FlowReservoirSampler) SampleWeight() float64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.seen <= s.capacity {
+		return 1.0
+	}
+	return float64(s.seen) / float64(s.capacity)
+}
+
+// Len returns the number of tuples currently held in the reservoir.
+func (s *FlowReservoirSampler) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.entries)
+}
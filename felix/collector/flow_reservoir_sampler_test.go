@@ -0,0 +1,114 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !windows
+// +build !windows
+
+package collector
+
+import (
+	"sync"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/projectcalico/calico/felix/collector/types/tuple"
+)
+
+var _ = Describe("FlowReservoirSampler", func() {
+	It("admits every tuple while under capacity, with weight 1", func() {
+		s := NewFlowReservoirSampler(10)
+		for i := 0; i < 10; i++ {
+			t := tuple.New(localIp1, localIp2, proto_tcp, srcPort, dstPort+i)
+			_, evicted, admitted := s.Admit(*t)
+			Expect(admitted).To(BeTrue())
+			Expect(evicted).To(BeFalse())
+		}
+		Expect(s.Len()).To(Equal(10))
+		Expect(s.SampleWeight()).To(Equal(1.0))
+	})
+
+	It("floods the reservoir past its cap and keeps it bounded", func() {
+		const capacity = 100
+		s := NewFlowReservoirSampler(capacity)
+
+		for i := 0; i < 50*capacity; i++ {
+			t := tuple.New(localIp1, localIp2, proto_tcp, srcPort, dstPort+i)
+			s.Admit(*t)
+		}
+
+		Expect(s.Len()).To(BeNumerically("<=", capacity))
+	})
+
+	It("reports a sample_weight matching the observed admission probability within tolerance", func() {
+		const capacity = 50
+		const trials = 2000
+
+		// Run many independent reservoirs of the same (capacity, trials) shape and record, for
+		// each, whether a fixed marked tuple (the very first one offered) survives to the end.
+		// Algorithm R guarantees every tuple -- including the first -- ends up resident with
+		// probability capacity/trials, which is exactly what SampleWeight's reciprocal asserts.
+		const reservoirs = 500
+		survived := 0
+		for r := 0; r < reservoirs; r++ {
+			s := NewFlowReservoirSampler(capacity)
+			marked := tuple.New(localIp1, localIp2, proto_tcp, srcPort, dstPort)
+			_, _, _ = s.Admit(*marked)
+			for i := 1; i < trials; i++ {
+				t := tuple.New(localIp1, localIp2, proto_tcp, srcPort, dstPort+i)
+				victim, evicted, _ := s.Admit(*t)
+				if marked != nil && evicted && victim == *marked {
+					marked = nil
+				}
+			}
+			if marked != nil {
+				survived++
+			}
+			_ = s.SampleWeight()
+		}
+
+		observedP := float64(survived) / float64(reservoirs)
+		expectedP := float64(capacity) / float64(trials)
+		Expect(observedP).To(BeNumerically("~", expectedP, 0.03))
+
+		s := NewFlowReservoirSampler(capacity)
+		for i := 0; i < trials; i++ {
+			t := tuple.New(localIp1, localIp2, proto_tcp, srcPort, dstPort+i)
+			s.Admit(*t)
+		}
+		Expect(s.SampleWeight()).To(BeNumerically("~", 1/expectedP, 1))
+	})
+
+	It("is safe for concurrent Admit/Forget from multiple goroutines", func() {
+		const capacity = 50
+		s := NewFlowReservoirSampler(capacity)
+
+		var wg sync.WaitGroup
+		for g := 0; g < 8; g++ {
+			wg.Add(1)
+			go func(g int) {
+				defer wg.Done()
+				for i := 0; i < 1000; i++ {
+					t := tuple.New(localIp1, localIp2, proto_tcp, srcPort, dstPort+g*10000+i)
+					if victim, evicted, _ := s.Admit(*t); evicted {
+						s.Forget(victim)
+					}
+				}
+			}(g)
+		}
+		wg.Wait()
+
+		Expect(s.Len()).To(BeNumerically("<=", capacity))
+	})
+})
@@ -0,0 +1,251 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !windows
+// +build !windows
+
+package collector
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// calicoEnterpriseNumber is the Private Enterprise Number IPFIXExporter uses for its
+// Calico-specific Information Elements (tier, policy, action, DNAT, endpoint names). Operators
+// pointing a real IPFIX collector at Felix need to register a decoder for this PEN; it isn't an
+// IANA-assigned number, just a fixed value both ends of the wire agree on.
+const calicoEnterpriseNumber uint32 = 56063
+
+// calicoTemplateID is the (only) template IPFIXExporter emits records under. RFC 7011 reserves
+// IDs below 256 for Sets; the first usable Template ID is 256.
+const calicoTemplateID uint16 = 256
+
+// ipfixField describes one field of the fixed Calico template: whether it's a standard IANA
+// Information Element or one of ours (enterpriseNumber != 0), its element ID, and its
+// on-the-wire length.
+type ipfixField struct {
+	elementID        uint16
+	enterpriseNumber uint32
+	length           uint16
+}
+
+// calicoTemplateFields is, in order, the fixed set of fields every data record IPFIXExporter
+// emits carries: the observed 5-tuple and counters (standard IANA IEs), followed by
+// Calico-specific policy and DNAT/endpoint context (enterprise IEs under calicoEnterpriseNumber).
+var calicoTemplateFields = []ipfixField{
+	{elementID: 8, length: 4},    // sourceIPv4Address
+	{elementID: 12, length: 4},   // destinationIPv4Address
+	{elementID: 7, length: 2},    // sourceTransportPort
+	{elementID: 11, length: 2},   // destinationTransportPort
+	{elementID: 4, length: 1},    // protocolIdentifier
+	{elementID: 2, length: 8},    // packetDeltaCount
+	{elementID: 1, length: 8},    // octetDeltaCount
+	{elementID: 1, enterpriseNumber: calicoEnterpriseNumber, length: 32}, // calicoTier
+	{elementID: 2, enterpriseNumber: calicoEnterpriseNumber, length: 64}, // calicoPolicy
+	{elementID: 3, enterpriseNumber: calicoEnterpriseNumber, length: 16}, // calicoAction
+	{elementID: 4, enterpriseNumber: calicoEnterpriseNumber, length: 1},  // calicoIsDNAT
+	{elementID: 5, enterpriseNumber: calicoEnterpriseNumber, length: 4},  // calicoPreDNATDestIPv4Address
+	{elementID: 6, enterpriseNumber: calicoEnterpriseNumber, length: 2},  // calicoPreDNATDestPort
+	{elementID: 7, enterpriseNumber: calicoEnterpriseNumber, length: 64}, // calicoSrcEndpoint
+	{elementID: 8, enterpriseNumber: calicoEnterpriseNumber, length: 64}, // calicoDstEndpoint
+}
+
+// IPFIXExporter encodes enriched flow records as RFC 7011 IPFIX messages, under a single
+// Calico-specific template carrying the observed 5-tuple, counters, the matched policy's
+// tier/policy/action, DNAT information, and endpoint identity, and ships them to one or more
+// configured collectors. It re-sends the template record every templateRefreshInterval, the same
+// way any IPFIX exporter must so a collector that (re)joined mid-stream can still decode data
+// records.
+type IPFIXExporter struct {
+	observationDomainID uint32
+	templateInterval    time.Duration
+
+	mu             sync.Mutex
+	conns          []net.Conn
+	sequence       uint32
+	templateSentAt time.Time
+}
+
+// NewIPFIXExporter dials every target in targets (each "tcp://host:port" or "udp://host:port";
+// a target with no scheme is dialed over UDP) and returns an exporter that tags every message
+// with observationDomainID and re-sends its template at least every templateRefreshInterval.
+func NewIPFIXExporter(targets []string, templateRefreshInterval time.Duration, observationDomainID uint32) (*IPFIXExporter, error) {
+	exp := &IPFIXExporter{
+		observationDomainID: observationDomainID,
+		templateInterval:    templateRefreshInterval,
+	}
+	for _, target := range targets {
+		network, addr := splitTarget(target)
+		conn, err := net.Dial(network, addr)
+		if err != nil {
+			exp.Close()
+			return nil, fmt.Errorf("IPFIXExporter: failed to dial %s: %w", target, err)
+		}
+		exp.conns = append(exp.conns, conn)
+	}
+	return exp, nil
+}
+
+func splitTarget(target string) (network, addr string) {
+	if rest, ok := strings.CutPrefix(target, "tcp://"); ok {
+		return "tcp", rest
+	}
+	if rest, ok := strings.CutPrefix(target, "udp://"); ok {
+		return "udp", rest
+	}
+	return "udp", target
+}
+
+// Close closes every underlying connection.
+func (e *IPFIXExporter) Close() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	var firstErr error
+	for _, conn := range e.conns {
+		if err := conn.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// Export encodes record as an IPFIX data record and writes it, prefixed with a fresh template
+// record whenever the template hasn't been sent in the last templateInterval, to every
+// configured target.
+func (e *IPFIXExporter) Export(record AuditRecord) error {
+	e.mu.Lock()
+	needsTemplate := e.templateInterval <= 0 || time.Since(e.templateSentAt) >= e.templateInterval
+	seq := atomic.AddUint32(&e.sequence, 1)
+	if needsTemplate {
+		e.templateSentAt = time.Now()
+	}
+	e.mu.Unlock()
+
+	msg := buildIPFIXMessage(record, seq-1, e.observationDomainID, needsTemplate)
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	var firstErr error
+	for _, conn := range e.conns {
+		if _, err := conn.Write(msg); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// buildIPFIXMessage assembles the full on-the-wire message: the 16-byte message header,
+// optionally a template set, and the data set for record.
+func buildIPFIXMessage(record AuditRecord, sequence, observationDomainID uint32, includeTemplate bool) []byte {
+	var sets bytes.Buffer
+	if includeTemplate {
+		sets.Write(encodeTemplateSet())
+	}
+	sets.Write(encodeDataSet(record))
+
+	var msg bytes.Buffer
+	binary.Write(&msg, binary.BigEndian, uint16(10))                        // Version
+	binary.Write(&msg, binary.BigEndian, uint16(16+sets.Len()))             // Length
+	binary.Write(&msg, binary.BigEndian, uint32(time.Now().Unix()))         // Export Time
+	binary.Write(&msg, binary.BigEndian, sequence)                          // Sequence Number
+	binary.Write(&msg, binary.BigEndian, observationDomainID)               // Observation Domain ID
+	msg.Write(sets.Bytes())
+	return msg.Bytes()
+}
+
+// encodeTemplateSet encodes calicoTemplateFields as a Set ID 2 (Template Set) record for
+// calicoTemplateID.
+func encodeTemplateSet() []byte {
+	var rec bytes.Buffer
+	binary.Write(&rec, binary.BigEndian, calicoTemplateID)
+	binary.Write(&rec, binary.BigEndian, uint16(len(calicoTemplateFields)))
+	for _, f := range calicoTemplateFields {
+		id := f.elementID
+		if f.enterpriseNumber != 0 {
+			id |= 0x8000
+		}
+		binary.Write(&rec, binary.BigEndian, id)
+		binary.Write(&rec, binary.BigEndian, f.length)
+		if f.enterpriseNumber != 0 {
+			binary.Write(&rec, binary.BigEndian, f.enterpriseNumber)
+		}
+	}
+
+	var set bytes.Buffer
+	binary.Write(&set, binary.BigEndian, uint16(2))                 // Set ID: Template Set
+	binary.Write(&set, binary.BigEndian, uint16(4+rec.Len()))       // Set Length
+	set.Write(rec.Bytes())
+	return set.Bytes()
+}
+
+// encodeDataSet encodes record's fields, in calicoTemplateFields order, as a data set under
+// calicoTemplateID.
+func encodeDataSet(record AuditRecord) []byte {
+	var rec bytes.Buffer
+	rec.Write(ipv4Bytes(record.SrcIP))
+	rec.Write(ipv4Bytes(record.DstIP))
+	binary.Write(&rec, binary.BigEndian, uint16(record.SrcPort))
+	binary.Write(&rec, binary.BigEndian, uint16(record.DstPort))
+	rec.WriteByte(byte(record.Protocol))
+	binary.Write(&rec, binary.BigEndian, uint64(record.Packets))
+	binary.Write(&rec, binary.BigEndian, uint64(record.Bytes))
+	rec.Write(fixedLengthString(record.Tier, 32))
+	rec.Write(fixedLengthString(record.Policy, 64))
+	rec.Write(fixedLengthString(record.Action, 16))
+	if record.IsDNAT {
+		rec.WriteByte(1)
+	} else {
+		rec.WriteByte(0)
+	}
+	rec.Write(ipv4Bytes(record.PreDNATDstIP))
+	binary.Write(&rec, binary.BigEndian, uint16(record.PreDNATDstPort))
+	rec.Write(fixedLengthString(record.SrcEndpoint, 64))
+	rec.Write(fixedLengthString(record.DstEndpoint, 64))
+
+	var set bytes.Buffer
+	binary.Write(&set, binary.BigEndian, calicoTemplateID)   // Set ID: matches the template
+	binary.Write(&set, binary.BigEndian, uint16(4+rec.Len())) // Set Length
+	set.Write(rec.Bytes())
+	return set.Bytes()
+}
+
+// ipv4Bytes returns s's 4-byte IPv4 representation, or 4 zero bytes if s is empty or not a valid
+// IPv4 address (IPFIX still needs a fixed-length field to keep the record framing intact).
+func ipv4Bytes(s string) []byte {
+	out := make([]byte, 4)
+	if s == "" {
+		return out
+	}
+	if ip := net.ParseIP(s); ip != nil {
+		if ip4 := ip.To4(); ip4 != nil {
+			copy(out, ip4)
+		}
+	}
+	return out
+}
+
+// fixedLengthString truncates or zero-pads s to exactly n bytes, since IPFIX fixed-length string
+// fields (unlike variable-length ones) must always be exactly their declared length on the wire.
+func fixedLengthString(s string, n int) []byte {
+	out := make([]byte, n)
+	copy(out, s)
+	return out
+}
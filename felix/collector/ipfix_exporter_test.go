@@ -0,0 +1,208 @@
+//go:build !windows
+// +build !windows
+
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"encoding/binary"
+	"net"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/projectcalico/calico/felix/calc"
+	clttypes "github.com/projectcalico/calico/felix/collector/types"
+	"github.com/projectcalico/calico/felix/nfnetlink"
+	"github.com/projectcalico/calico/felix/rules"
+)
+
+// decodedIPFIXDataRecord is the subset of a decoded Calico data record that the tests below
+// assert on; it mirrors calicoTemplateFields' ordering, not the full RFC 7011 field set.
+type decodedIPFIXDataRecord struct {
+	srcIP, dstIP           net.IP
+	srcPort, dstPort       uint16
+	protocol               byte
+	packets, bytes         uint64
+	tier, policy, action   string
+	isDNAT                 bool
+	preDNATDstIP           net.IP
+	preDNATDstPort         uint16
+	srcEndpoint, dstEndpoint string
+}
+
+// decodeIPFIXMessage parses a single IPFIX message built by buildIPFIXMessage back into its
+// template set (if present) and its one data record, so tests can assert on the wire format
+// without needing a full collector implementation.
+func decodeIPFIXMessage(msg []byte) (hasTemplate bool, rec decodedIPFIXDataRecord) {
+	Expect(len(msg)).To(BeNumerically(">=", 16))
+	Expect(binary.BigEndian.Uint16(msg[0:2])).To(Equal(uint16(10)))
+
+	offset := 16
+	for offset < len(msg) {
+		setID := binary.BigEndian.Uint16(msg[offset : offset+2])
+		setLen := int(binary.BigEndian.Uint16(msg[offset+2 : offset+4]))
+		body := msg[offset+4 : offset+setLen]
+
+		switch setID {
+		case 2:
+			hasTemplate = true
+		case calicoTemplateID:
+			rec = decodeCalicoDataRecord(body)
+		}
+		offset += setLen
+	}
+	return hasTemplate, rec
+}
+
+func decodeCalicoDataRecord(body []byte) decodedIPFIXDataRecord {
+	var rec decodedIPFIXDataRecord
+	pos := 0
+	readN := func(n int) []byte {
+		b := body[pos : pos+n]
+		pos += n
+		return b
+	}
+
+	rec.srcIP = net.IP(readN(4))
+	rec.dstIP = net.IP(readN(4))
+	rec.srcPort = binary.BigEndian.Uint16(readN(2))
+	rec.dstPort = binary.BigEndian.Uint16(readN(2))
+	rec.protocol = readN(1)[0]
+	rec.packets = binary.BigEndian.Uint64(readN(8))
+	rec.bytes = binary.BigEndian.Uint64(readN(8))
+	rec.tier = trimNulls(readN(32))
+	rec.policy = trimNulls(readN(64))
+	rec.action = trimNulls(readN(16))
+	rec.isDNAT = readN(1)[0] != 0
+	rec.preDNATDstIP = net.IP(readN(4))
+	rec.preDNATDstPort = binary.BigEndian.Uint16(readN(2))
+	rec.srcEndpoint = trimNulls(readN(64))
+	rec.dstEndpoint = trimNulls(readN(64))
+	return rec
+}
+
+func trimNulls(b []byte) string {
+	for i, c := range b {
+		if c == 0 {
+			return string(b[:i])
+		}
+	}
+	return string(b)
+}
+
+var _ = Describe("IPFIXExporter", func() {
+	var (
+		listener *net.UDPConn
+		targets  []string
+		exp      *IPFIXExporter
+	)
+
+	BeforeEach(func() {
+		var err error
+		listener, err = net.ListenUDP("udp", &net.UDPAddr{IP: net.ParseIP("127.0.0.1")})
+		Expect(err).NotTo(HaveOccurred())
+		targets = []string{"udp://" + listener.LocalAddr().String()}
+	})
+
+	AfterEach(func() {
+		if exp != nil {
+			exp.Close()
+		}
+		listener.Close()
+	})
+
+	recvMessage := func() []byte {
+		buf := make([]byte, 4096)
+		listener.SetReadDeadline(time.Now().Add(2 * time.Second))
+		n, _, err := listener.ReadFromUDP(buf)
+		Expect(err).NotTo(HaveOccurred())
+		return buf[:n]
+	}
+
+	It("encodes the original and post-NAT tuples from a DNAT fixture correctly", func() {
+		var err error
+		exp, err = NewIPFIXExporter(targets, time.Minute, 1234)
+		Expect(err).NotTo(HaveOccurred())
+
+		a := NewAuditLogger(calc.NewLookupsCache(), nil, 1.0, 0, 0)
+		a.SetIPFIXExporter(exp)
+
+		// localPktIngressWithDNAT: a client dials 10.0.0.1:8080, which conntrack (per
+		// inCtEntryWithDNAT) shows was DNAT'd from the service VIP the client actually targeted.
+		agg := &nfnetlink.NflogPacketAggregate{
+			Tuple: nfnetlink.NflogPacketTuple{
+				Src:   ipTo16Byte("20.0.0.1"),
+				Dst:   ipTo16Byte("10.0.0.1"),
+				Proto: 6,
+				L4Src: nfnetlink.NflogL4Info{Port: 20000},
+				L4Dst: nfnetlink.NflogL4Info{Port: 8080},
+			},
+			Prefixes: []nfnetlink.NflogPrefix{{Bytes: 100, Packets: 1}},
+		}
+		ruleID := &calc.RuleID{
+			PolicyID: calc.PolicyID{Tier: "default", Name: "allow-dnat"},
+			IndexStr: "0",
+			Action:   rules.RuleActionAllow,
+		}
+
+		inCtEntryWithDNAT := clttypes.ConntrackInfo{
+			SrcIP: net.ParseIP("20.0.0.1"), DstIP: net.ParseIP("10.0.0.1"),
+			SrcPort: 20000, DstPort: 8080, Protocol: 6,
+			IsDNAT:      true,
+			PreDNATAddr: net.ParseIP("192.168.10.10"),
+			PreDNATPort: 80,
+		}
+
+		a.ctInfo = map[fiveTupleKey]clttypes.ConntrackInfo{
+			fiveTupleKeyFor(net.ParseIP("20.0.0.1"), net.ParseIP("10.0.0.1"), 20000, 8080, 6): inCtEntryWithDNAT,
+		}
+
+		record := a.buildRecord(rules.RuleDirIngress, agg, agg.Prefixes[0], ruleID)
+		a.emit(record)
+
+		msg := recvMessage()
+		hasTemplate, decoded := decodeIPFIXMessage(msg)
+
+		Expect(hasTemplate).To(BeTrue())
+		Expect(decoded.srcIP.String()).To(Equal("20.0.0.1"))
+		Expect(decoded.dstIP.String()).To(Equal("10.0.0.1"))
+		Expect(decoded.srcPort).To(Equal(uint16(20000)))
+		Expect(decoded.dstPort).To(Equal(uint16(8080)))
+		Expect(decoded.tier).To(Equal("default"))
+		Expect(decoded.policy).To(Equal("allow-dnat"))
+		Expect(decoded.action).To(Equal("Allow"))
+		Expect(decoded.isDNAT).To(BeTrue())
+		Expect(decoded.preDNATDstIP.String()).To(Equal("192.168.10.10"))
+		Expect(decoded.preDNATDstPort).To(Equal(uint16(80)))
+	})
+
+	It("omits the template set once it's been sent within the refresh interval", func() {
+		var err error
+		exp, err = NewIPFIXExporter(targets, time.Minute, 1234)
+		Expect(err).NotTo(HaveOccurred())
+
+		record := AuditRecord{SrcIP: "1.2.3.4", DstIP: "5.6.7.8", Packets: 1, Bytes: 10}
+		Expect(exp.Export(record)).NotTo(HaveOccurred())
+		hasTemplate, _ := decodeIPFIXMessage(recvMessage())
+		Expect(hasTemplate).To(BeTrue())
+
+		Expect(exp.Export(record)).NotTo(HaveOccurred())
+		hasTemplate, _ = decodeIPFIXMessage(recvMessage())
+		Expect(hasTemplate).To(BeFalse())
+	})
+})
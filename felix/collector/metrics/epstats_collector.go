@@ -0,0 +1,166 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+)
+
+// epStatsLabelNames are, in order, the label values EpStatsEntry.labels() must return.
+var epStatsLabelNames = []string{
+	"src_namespace", "src_pod", "src_workload",
+	"dst_namespace", "dst_pod", "dst_workload",
+	"protocol", "dst_service", "action", "policy", "tier", "rule",
+}
+
+// EpStatsEntry is a read-only view of one collector.Data flow record, carrying just the label
+// dimensions and counter values EpStatsCollector needs. EpStatsCollector gets these from the
+// snapshot function passed to NewEpStatsCollector and never touches the collector's internal
+// map; the collector package has no function that builds them from c.epStats.
+type EpStatsEntry struct {
+	SrcNamespace, SrcPod, SrcWorkload string
+	DstNamespace, DstPod, DstWorkload string
+	Protocol                         string
+	DstService                       string
+	Action, Policy, Tier, Rule       string
+
+	ConntrackPackets        float64
+	ConntrackPacketsReverse float64
+	ConntrackBytes          float64
+	ConntrackBytesReverse   float64
+
+	AllowedPackets, AllowedBytes float64
+	DeniedPackets, DeniedBytes   float64
+}
+
+func (e EpStatsEntry) labels() []string {
+	return []string{
+		e.SrcNamespace, e.SrcPod, e.SrcWorkload,
+		e.DstNamespace, e.DstPod, e.DstWorkload,
+		e.Protocol, e.DstService, e.Action, e.Policy, e.Tier, e.Rule,
+	}
+}
+
+// DefaultMaxEpStatsSeries bounds how many distinct EpStatsEntry label combinations
+// EpStatsCollector will emit as their own series on a single Collect() call, the epStats
+// analogue of PolicyCounters.DefaultMaxLabelCombinations. Above this, every further entry in the
+// same Collect() is folded into a single "__overflow__" series instead of its own -- otherwise a
+// cluster with many unresolved remote IPs (no src/dst endpoint, no dst service) would mint one
+// series per distinct IP pair and grow scrape cardinality without bound.
+const DefaultMaxEpStatsSeries = 10000
+
+// EpStatsCollector is a prometheus.Collector that, on every Collect(), walks a live snapshot of
+// the collector's flow table and emits one gauge sample per flow per counter -- modelled on how
+// the procfs collectors walk /proc on every scrape rather than maintaining their own running
+// totals, so scrape cost is proportional to the number of active flows rather than to traffic
+// volume. snapshot is expected to do its own locking and return promptly, since it runs
+// synchronously inside Collect().
+type EpStatsCollector struct {
+	snapshot  func() []EpStatsEntry
+	maxSeries int
+
+	conntrackPackets        *prometheus.Desc
+	conntrackPacketsReverse *prometheus.Desc
+	conntrackBytes          *prometheus.Desc
+	conntrackBytesReverse   *prometheus.Desc
+	allowedPackets          *prometheus.Desc
+	allowedBytes            *prometheus.Desc
+	deniedPackets           *prometheus.Desc
+	deniedBytes             *prometheus.Desc
+}
+
+// NewEpStatsCollector returns an EpStatsCollector that calls snapshot on every Collect() and
+// emits at most maxSeries flows as their own series, folding the rest into an overflow bucket.
+// maxSeries <= 0 means DefaultMaxEpStatsSeries.
+func NewEpStatsCollector(snapshot func() []EpStatsEntry, maxSeries int) *EpStatsCollector {
+	if maxSeries <= 0 {
+		maxSeries = DefaultMaxEpStatsSeries
+	}
+	desc := func(name, help string) *prometheus.Desc {
+		return prometheus.NewDesc(name, help, epStatsLabelNames, nil)
+	}
+	return &EpStatsCollector{
+		snapshot:  snapshot,
+		maxSeries: maxSeries,
+
+		conntrackPackets:        desc("calico_flow_conntrack_packets", "Conntrack packet count in the original direction, as of the last scrape."),
+		conntrackPacketsReverse: desc("calico_flow_conntrack_packets_reverse", "Conntrack packet count in the reply direction, as of the last scrape."),
+		conntrackBytes:          desc("calico_flow_conntrack_bytes", "Conntrack byte count in the original direction, as of the last scrape."),
+		conntrackBytesReverse:   desc("calico_flow_conntrack_bytes_reverse", "Conntrack byte count in the reply direction, as of the last scrape."),
+		allowedPackets:          desc("calico_flow_allowed_packets", "NFLOG-derived allowed packet count, as of the last scrape."),
+		allowedBytes:            desc("calico_flow_allowed_bytes", "NFLOG-derived allowed byte count, as of the last scrape."),
+		deniedPackets:           desc("calico_flow_denied_packets", "NFLOG-derived denied packet count, as of the last scrape."),
+		deniedBytes:             desc("calico_flow_denied_bytes", "NFLOG-derived denied byte count, as of the last scrape."),
+	}
+}
+
+func (c *EpStatsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.conntrackPackets
+	ch <- c.conntrackPacketsReverse
+	ch <- c.conntrackBytes
+	ch <- c.conntrackBytesReverse
+	ch <- c.allowedPackets
+	ch <- c.allowedBytes
+	ch <- c.deniedPackets
+	ch <- c.deniedBytes
+}
+
+func (c *EpStatsCollector) Collect(ch chan<- prometheus.Metric) {
+	entries := c.snapshot()
+
+	var overflow EpStatsEntry
+	overflow.SrcNamespace, overflow.SrcPod, overflow.SrcWorkload = overflowLabel, overflowLabel, overflowLabel
+	overflow.DstNamespace, overflow.DstPod, overflow.DstWorkload = overflowLabel, overflowLabel, overflowLabel
+	overflow.Protocol, overflow.DstService = overflowLabel, overflowLabel
+	overflow.Action, overflow.Policy, overflow.Tier, overflow.Rule = overflowLabel, overflowLabel, overflowLabel, overflowLabel
+	overflowed := 0
+
+	emitted := 0
+	for _, e := range entries {
+		if emitted >= c.maxSeries {
+			overflow.ConntrackPackets += e.ConntrackPackets
+			overflow.ConntrackPacketsReverse += e.ConntrackPacketsReverse
+			overflow.ConntrackBytes += e.ConntrackBytes
+			overflow.ConntrackBytesReverse += e.ConntrackBytesReverse
+			overflow.AllowedPackets += e.AllowedPackets
+			overflow.AllowedBytes += e.AllowedBytes
+			overflow.DeniedPackets += e.DeniedPackets
+			overflow.DeniedBytes += e.DeniedBytes
+			overflowed++
+			continue
+		}
+		c.emit(ch, e)
+		emitted++
+	}
+
+	if overflowed > 0 {
+		log.WithFields(log.Fields{"maxSeries": c.maxSeries, "folded": overflowed}).Warn(
+			"EpStatsCollector: label cardinality cap reached, folding further flows into an overflow bucket")
+		c.emit(ch, overflow)
+	}
+}
+
+func (c *EpStatsCollector) emit(ch chan<- prometheus.Metric, e EpStatsEntry) {
+	labels := e.labels()
+	ch <- prometheus.MustNewConstMetric(c.conntrackPackets, prometheus.GaugeValue, e.ConntrackPackets, labels...)
+	ch <- prometheus.MustNewConstMetric(c.conntrackPacketsReverse, prometheus.GaugeValue, e.ConntrackPacketsReverse, labels...)
+	ch <- prometheus.MustNewConstMetric(c.conntrackBytes, prometheus.GaugeValue, e.ConntrackBytes, labels...)
+	ch <- prometheus.MustNewConstMetric(c.conntrackBytesReverse, prometheus.GaugeValue, e.ConntrackBytesReverse, labels...)
+	ch <- prometheus.MustNewConstMetric(c.allowedPackets, prometheus.GaugeValue, e.AllowedPackets, labels...)
+	ch <- prometheus.MustNewConstMetric(c.allowedBytes, prometheus.GaugeValue, e.AllowedBytes, labels...)
+	ch <- prometheus.MustNewConstMetric(c.deniedPackets, prometheus.GaugeValue, e.DeniedPackets, labels...)
+	ch <- prometheus.MustNewConstMetric(c.deniedBytes, prometheus.GaugeValue, e.DeniedBytes, labels...)
+}
@@ -0,0 +1,111 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"fmt"
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// overflowGaugeValue gathers metricName from c and returns the value of the sample whose labels
+// are all overflowLabel, the one EpStatsCollector.Collect folds the flows past maxSeries into.
+func overflowGaugeValue(c *EpStatsCollector, metricName string) float64 {
+	reg := prometheus.NewPedanticRegistry()
+	ExpectWithOffset(1, reg.Register(c)).To(Succeed())
+	mfs, err := reg.Gather()
+	ExpectWithOffset(1, err).NotTo(HaveOccurred())
+
+	for _, mf := range mfs {
+		if mf.GetName() != metricName {
+			continue
+		}
+		for _, m := range mf.GetMetric() {
+			isOverflow := true
+			for _, l := range m.GetLabel() {
+				if l.GetValue() != overflowLabel {
+					isOverflow = false
+					break
+				}
+			}
+			if isOverflow {
+				return m.GetGauge().GetValue()
+			}
+		}
+	}
+	return 0
+}
+
+func entryForTuple(i int) EpStatsEntry {
+	return EpStatsEntry{
+		SrcNamespace: "ns", SrcPod: fmt.Sprintf("pod-%d", i), SrcWorkload: "wl",
+		DstNamespace: "ns", DstPod: "dst-pod", DstWorkload: "wl",
+		Protocol: "tcp", DstService: "svc", Action: "Allow", Policy: "policy1", Tier: "default", Rule: "0",
+
+		ConntrackPackets: 1, ConntrackPacketsReverse: 2, ConntrackBytes: 100, ConntrackBytesReverse: 200,
+		AllowedPackets: 1, AllowedBytes: 100,
+	}
+}
+
+var _ = Describe("EpStatsCollector", func() {
+	It("emits one series per flow when under the cardinality cap", func() {
+		entries := []EpStatsEntry{entryForTuple(1), entryForTuple(2)}
+		c := NewEpStatsCollector(func() []EpStatsEntry { return entries }, 10)
+
+		Expect(testutil.CollectAndCount(c)).To(Equal(len(entries) * 8))
+	})
+
+	It("folds flows past maxSeries into a single overflow series instead of dropping them", func() {
+		entries := []EpStatsEntry{entryForTuple(1), entryForTuple(2), entryForTuple(3)}
+		c := NewEpStatsCollector(func() []EpStatsEntry { return entries }, 1)
+
+		// 1 real series + 1 overflow series, each contributing 8 metrics.
+		Expect(testutil.CollectAndCount(c)).To(Equal(2 * 8))
+
+		c2 := NewEpStatsCollector(func() []EpStatsEntry { return entries }, 1)
+		overflowPackets := overflowGaugeValue(c2, "calico_flow_conntrack_packets")
+		Expect(overflowPackets).To(Equal(2.0)) // the 2 folded flows' ConntrackPackets=1 each
+	})
+})
+
+// BenchmarkEpStatsCollectorCollect10k and ...Collect100k measure Collect() cost at the flow
+// counts this collector's cardinality guard is sized around, the metrics-package analogue of
+// collector.BenchmarkApplyStatUpdate.
+func BenchmarkEpStatsCollectorCollect10k(b *testing.B) {
+	benchmarkEpStatsCollectorCollect(b, 10000)
+}
+
+func BenchmarkEpStatsCollectorCollect100k(b *testing.B) {
+	benchmarkEpStatsCollectorCollect(b, 100000)
+}
+
+func benchmarkEpStatsCollectorCollect(b *testing.B, n int) {
+	entries := make([]EpStatsEntry, n)
+	for i := range entries {
+		entries[i] = entryForTuple(i)
+	}
+	c := NewEpStatsCollector(func() []EpStatsEntry { return entries }, DefaultMaxEpStatsSeries)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ch := make(chan prometheus.Metric, len(entries)*8+8)
+		c.Collect(ch)
+		close(ch)
+	}
+}
@@ -0,0 +1,108 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package metrics exposes Prometheus counters for packets and bytes seen per policy decision,
+// broken down by (tier, policy, action, direction, namespace). It's updated from the same NFLOG
+// processing path that feeds the collector's per-tuple flow stats, so operators get a
+// low-cardinality, always-on view of policy hit rates without needing flow logs enabled.
+package metrics
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+)
+
+var labelNames = []string{"tier", "policy", "action", "direction", "namespace"}
+
+var (
+	packetsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "calico_policy_packets_total",
+		Help: "Total packets matching a policy rule, broken down by tier, policy, action, direction and namespace.",
+	}, labelNames)
+
+	bytesTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "calico_policy_bytes_total",
+		Help: "Total bytes matching a policy rule, broken down by tier, policy, action, direction and namespace.",
+	}, labelNames)
+)
+
+func init() {
+	prometheus.MustRegister(packetsTotal, bytesTotal)
+}
+
+// DefaultMaxLabelCombinations bounds the number of distinct (tier, policy, action, direction,
+// namespace) combinations PolicyCounters will track before it starts folding new combinations
+// into an overflow bucket, so a policy set that churns through many tiers/namespaces (or a
+// misconfiguration generating unbounded policy names) can't grow the counter's cardinality
+// without limit.
+const DefaultMaxLabelCombinations = 10000
+
+// overflowLabel replaces every label value once a PolicyCounters has seen
+// DefaultMaxLabelCombinations distinct combinations, so further traffic is still counted, just
+// no longer broken down by its real labels.
+const overflowLabel = "__overflow__"
+
+// PolicyCounters wraps the packetsTotal/bytesTotal CounterVecs with a cap on the number of
+// distinct label combinations it will create, so the registry's memory use stays bounded
+// regardless of how many (tier, policy, action, direction, namespace) tuples traffic produces.
+type PolicyCounters struct {
+	maxCombinations int
+
+	mu   sync.Mutex
+	seen map[string]struct{}
+}
+
+// NewPolicyCounters returns a PolicyCounters that stops minting new label combinations once it
+// has seen maxCombinations distinct ones, folding everything after that into a shared overflow
+// bucket. maxCombinations <= 0 means DefaultMaxLabelCombinations.
+func NewPolicyCounters(maxCombinations int) *PolicyCounters {
+	if maxCombinations <= 0 {
+		maxCombinations = DefaultMaxLabelCombinations
+	}
+	return &PolicyCounters{
+		maxCombinations: maxCombinations,
+		seen:            map[string]struct{}{},
+	}
+}
+
+// Record increments the packet/byte counters for one policy decision. tier/policy/action/
+// direction/namespace identify the decision; packets/bytes are the counts to add.
+func (p *PolicyCounters) Record(tier, policy, action, direction, namespace string, packets, bytes int) {
+	tier, policy, action, direction, namespace = p.boundedLabels(tier, policy, action, direction, namespace)
+	packetsTotal.WithLabelValues(tier, policy, action, direction, namespace).Add(float64(packets))
+	bytesTotal.WithLabelValues(tier, policy, action, direction, namespace).Add(float64(bytes))
+}
+
+// boundedLabels returns the labels unchanged if this combination has already been seen or there
+// is still room under maxCombinations; otherwise it returns overflowLabel for every field so the
+// traffic is still counted without minting a new time series.
+func (p *PolicyCounters) boundedLabels(tier, policy, action, direction, namespace string) (string, string, string, string, string) {
+	key := tier + "\x00" + policy + "\x00" + action + "\x00" + direction + "\x00" + namespace
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if _, ok := p.seen[key]; ok {
+		return tier, policy, action, direction, namespace
+	}
+	if len(p.seen) >= p.maxCombinations {
+		log.WithField("maxCombinations", p.maxCombinations).Warn(
+			"PolicyCounters: label cardinality cap reached, folding further combinations into an overflow bucket")
+		return overflowLabel, overflowLabel, overflowLabel, overflowLabel, overflowLabel
+	}
+	p.seen[key] = struct{}{}
+	return tier, policy, action, direction, namespace
+}
@@ -0,0 +1,56 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestMetrics(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "felix/collector/metrics Suite")
+}
+
+var _ = Describe("PolicyCounters", func() {
+	It("increments packets/bytes for an ingress allow decision", func() {
+		pc := NewPolicyCounters(0)
+		pc.Record("default", "policy1", "Allow", "Ingress", "", 1, 100)
+
+		Expect(testutil.CollectAndCount(packetsTotal)).To(BeNumerically(">=", 1))
+		Expect(testutil.ToFloat64(packetsTotal.WithLabelValues("default", "policy1", "Allow", "Ingress", ""))).To(Equal(1.0))
+		Expect(testutil.ToFloat64(bytesTotal.WithLabelValues("default", "policy1", "Allow", "Ingress", ""))).To(Equal(100.0))
+	})
+
+	It("increments packets/bytes for an ingress deny decision independently of allow", func() {
+		pc := NewPolicyCounters(0)
+		pc.Record("default", "policy2", "Deny", "Ingress", "", 1, 50)
+
+		Expect(testutil.ToFloat64(packetsTotal.WithLabelValues("default", "policy2", "Deny", "Ingress", ""))).To(Equal(1.0))
+		Expect(testutil.ToFloat64(bytesTotal.WithLabelValues("default", "policy2", "Deny", "Ingress", ""))).To(Equal(50.0))
+	})
+
+	It("folds combinations into an overflow bucket once the cap is reached", func() {
+		pc := NewPolicyCounters(1)
+		pc.Record("default", "policy-a", "Allow", "Ingress", "", 1, 10)
+		pc.Record("default", "policy-b", "Allow", "Ingress", "", 1, 20)
+
+		Expect(testutil.ToFloat64(packetsTotal.WithLabelValues("default", "policy-b", "Allow", "Ingress", ""))).To(Equal(0.0))
+		Expect(testutil.ToFloat64(packetsTotal.WithLabelValues(overflowLabel, overflowLabel, overflowLabel, overflowLabel, overflowLabel))).To(BeNumerically(">=", 1))
+	})
+})
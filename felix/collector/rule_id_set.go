@@ -0,0 +1,44 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !windows
+// +build !windows
+
+package collector
+
+import "github.com/projectcalico/calico/felix/calc"
+
+// equalRuleIDSets is equal's set-based counterpart: where equal requires a and b to match
+// element-for-element in order, equalRuleIDSets only requires them to contain the same rules,
+// identified by calc.RuleID.CanonicalID(fields) rather than by position, so two lists that differ
+// only in order, or in fields the hash leaves out, compare equal. updatePendingRuleTraces does not
+// call it; it compares pending rule traces with equal.
+func equalRuleIDSets(a, b []*calc.RuleID, fields calc.RuleIDHashFields) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	counts := make(map[string]int, len(a))
+	for _, ruleID := range a {
+		counts[ruleID.CanonicalID(fields)]++
+	}
+	for _, ruleID := range b {
+		id := ruleID.CanonicalID(fields)
+		if counts[id] == 0 {
+			return false
+		}
+		counts[id]--
+	}
+	return true
+}
@@ -0,0 +1,37 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !windows
+// +build !windows
+
+package collector
+
+import "github.com/projectcalico/calico/felix/calc"
+
+// attachRuleProvenance pairs each entry in ruleIDs with the calc.RuleProvenance the policy
+// resolver recorded for it (matched by CanonicalID). A ruleID with no entry in byCanonicalID (the
+// resolver never recorded why it matched) gets the zero RuleProvenance rather than being dropped,
+// so the result stays index-aligned with ruleIDs. updatePendingRuleTraces does not call it, and
+// Data has no field that stores the result.
+func attachRuleProvenance(
+	ruleIDs []*calc.RuleID,
+	byCanonicalID map[string]calc.RuleProvenance,
+	fields calc.RuleIDHashFields,
+) []calc.RuleProvenance {
+	out := make([]calc.RuleProvenance, len(ruleIDs))
+	for i, r := range ruleIDs {
+		out[i] = byCanonicalID[r.CanonicalID(fields)]
+	}
+	return out
+}
@@ -0,0 +1,121 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !windows
+// +build !windows
+
+package collector
+
+import (
+	"fmt"
+
+	"github.com/projectcalico/calico/app-policy/policystore"
+	"github.com/projectcalico/calico/felix/calc"
+	"github.com/projectcalico/calico/felix/proto"
+	"github.com/projectcalico/calico/felix/rules"
+	"github.com/projectcalico/calico/felix/types"
+)
+
+// RuleTraceSimulator answers "if a hypothetical packet were sent between these two workloads
+// right now, which tier/policy/rule would match, in what order?" without waiting for real flow
+// data. It walks the same Tiers -> {Ingress,Egress}Policies -> PolicyByID ->
+// {Inbound,Outbound}Rules chain updatePendingRuleTraces evaluates against live epStats entries,
+// but against an arbitrary endpoint pair and, optionally, a PolicyStore snapshot the caller
+// loaded from YAML instead of the collector's live policyStoreManager - so a policy change can be
+// previewed before it's applied.
+//
+// This only covers the action/pass-chain walk itself; selector, port, protocol, service-account
+// and HTTP-match evaluation on each proto.Rule, and the gRPC/HTTP endpoint that would expose this
+// to an operator, are follow-up work.
+type RuleTraceSimulator struct {
+	policyStoreManager *policystore.PolicyStoreManager
+}
+
+// NewRuleTraceSimulator builds a RuleTraceSimulator that, absent an override PolicyStore passed
+// to SimulateRuleTrace, reads from policyStoreManager's live snapshot.
+func NewRuleTraceSimulator(policyStoreManager *policystore.PolicyStoreManager) *RuleTraceSimulator {
+	return &RuleTraceSimulator{policyStoreManager: policyStoreManager}
+}
+
+// SimulateRuleTrace resolves the ordered chain of RuleIDs that would match a packet between
+// srcID and dstID: egress is the chain srcID's Tiers apply, ingress is the chain dstID's Tiers
+// apply. If override is non-nil it is consulted instead of the simulator's live
+// policyStoreManager, letting a caller preview a policy change loaded from YAML without
+// installing it.
+func (s *RuleTraceSimulator) SimulateRuleTrace(
+	srcID, dstID types.WorkloadEndpointID,
+	override *policystore.PolicyStore,
+) (ingress, egress []*calc.RuleID, err error) {
+	eval := func(ps *policystore.PolicyStore) {
+		if srcEp, ok := ps.Endpoints[srcID]; ok {
+			egress = traceRuleChain(ps, srcEp, rules.RuleDirEgress)
+		}
+		if dstEp, ok := ps.Endpoints[dstID]; ok {
+			ingress = traceRuleChain(ps, dstEp, rules.RuleDirIngress)
+		}
+	}
+
+	if override != nil {
+		eval(override)
+		return ingress, egress, nil
+	}
+	if s.policyStoreManager == nil {
+		return nil, nil, fmt.Errorf("rule trace simulator has no policy store to evaluate against")
+	}
+	s.policyStoreManager.DoWithLock(eval)
+	return ingress, egress, nil
+}
+
+// traceRuleChain walks ep's Tiers in order and, within each tier, the policies named by
+// IngressPolicies/EgressPolicies (for dir == RuleDirIngress/RuleDirEgress respectively),
+// appending every rule from InboundRules/OutboundRules up to and including the first one whose
+// action isn't "pass"/"next-tier" - a pass rule continues the walk into the next tier, matching
+// how a live dataplane evaluation only short-circuits on a non-pass verdict.
+func traceRuleChain(ps *policystore.PolicyStore, ep *proto.WorkloadEndpoint, dir rules.RuleDirection) []*calc.RuleID {
+	var chain []*calc.RuleID
+tiers:
+	for _, tier := range ep.Tiers {
+		policyNames := tier.IngressPolicies
+		if dir == rules.RuleDirEgress {
+			policyNames = tier.EgressPolicies
+		}
+		for _, policyName := range policyNames {
+			policy, ok := ps.PolicyByID[types.PolicyID{Tier: tier.Name, Name: policyName}]
+			if !ok {
+				continue
+			}
+			protoRules := policy.InboundRules
+			if dir == rules.RuleDirEgress {
+				protoRules = policy.OutboundRules
+			}
+			for ruleIdx, rule := range protoRules {
+				ruleID := calc.NewRuleID(tier.Name, policyName, "", ruleIdx, dir, protoRuleAction(rule.Action))
+				chain = append(chain, ruleID)
+				if rule.Action == "pass" || rule.Action == "next-tier" {
+					continue tiers
+				}
+				return chain
+			}
+		}
+	}
+	return chain
+}
+
+// protoRuleAction maps a proto.Rule's string Action to the rules.RuleAction enum RuleID expects.
+func protoRuleAction(action string) rules.RuleAction {
+	if action == "deny" {
+		return rules.RuleActionDeny
+	}
+	return rules.RuleActionAllow
+}
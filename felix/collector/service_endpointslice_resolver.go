@@ -0,0 +1,65 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !windows
+// +build !windows
+
+package collector
+
+import (
+	log "github.com/sirupsen/logrus"
+	discoveryv1 "k8s.io/api/discovery/v1"
+
+	"github.com/projectcalico/calico/felix/calc"
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/model"
+)
+
+// ServiceEndpointSliceResolver keeps the LookupsCache's EndpointSlice-backed service index up to
+// date, so the collector's pre-DNAT rematch path can attribute a post-DNAT tuple to its owning
+// Service even when the ClusterIP/Port match used for regular services doesn't apply: headless
+// services DNAT straight to a pod IP, and services with more than one backend port can have a
+// pod-side port that differs from the port the Service itself exposes. The calc graph does not
+// call Update or Delete; a caller feeds it each EndpointSlice add/update/delete itself.
+type ServiceEndpointSliceResolver struct {
+	lookups *calc.LookupsCache
+}
+
+// NewServiceEndpointSliceResolver builds a ServiceEndpointSliceResolver that maintains lookups'
+// EndpointSlice-backed service index.
+func NewServiceEndpointSliceResolver(lookups *calc.LookupsCache) *ServiceEndpointSliceResolver {
+	return &ServiceEndpointSliceResolver{lookups: lookups}
+}
+
+// Update registers (or replaces) the backend addresses/ports that slice advertises, keyed from
+// the slice's "kubernetes.io/service-name" label, so that GetServiceFromEndpointSlice can map a
+// (podIP, podPort) pair straight back to the owning Service.
+func (r *ServiceEndpointSliceResolver) Update(key model.ResourceKey, slice *discoveryv1.EndpointSlice) {
+	svcName, ok := slice.Labels[discoveryv1.LabelServiceName]
+	if !ok {
+		log.WithField("endpointSlice", key).Debug(
+			"ServiceEndpointSliceResolver: ignoring EndpointSlice with no owning service label")
+		return
+	}
+	svcKey := model.ResourceKey{
+		Kind:      model.KindKubernetesService,
+		Name:      svcName,
+		Namespace: key.Namespace,
+	}
+	r.lookups.SetEndpointSliceBackend(key, svcKey, slice.Endpoints, slice.Ports)
+}
+
+// Delete removes the backend index entries that Update previously installed for key.
+func (r *ServiceEndpointSliceResolver) Delete(key model.ResourceKey) {
+	r.lookups.RemoveEndpointSliceBackend(key)
+}
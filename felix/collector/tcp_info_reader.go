@@ -0,0 +1,117 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !windows
+// +build !windows
+
+package collector
+
+import (
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	clttypes "github.com/projectcalico/calico/felix/collector/types"
+	"github.com/projectcalico/calico/felix/nfnetlink/inetdiag"
+)
+
+// TCPInfoReader supplies periodic TCPInfo snapshots for local TCP sockets. NewNetlinkTCPInfoReader
+// is the production implementation; tests substitute a fake that publishes synthetic records
+// without touching any sockets.
+type TCPInfoReader interface {
+	Start()
+	Stop()
+	TCPInfoChan() <-chan clttypes.TCPInfo
+}
+
+// NetlinkTCPInfoReader polls the kernel for struct tcp_info on every local TCP socket via
+// SOCK_DIAG_BY_FAMILY/inet_diag, the same netlink mechanism `ss` uses. maxTrackedSockets bounds
+// how many sockets are reported per poll, so a host with a huge number of connections can't
+// make this unboundedly expensive.
+type NetlinkTCPInfoReader struct {
+	pollInterval      time.Duration
+	maxTrackedSockets int
+
+	out chan clttypes.TCPInfo
+
+	wg     sync.WaitGroup
+	stopC  chan struct{}
+	stopCO sync.Once
+}
+
+// NewNetlinkTCPInfoReader builds a reader that polls every pollInterval, reporting at most
+// maxTrackedSockets sockets per poll.
+func NewNetlinkTCPInfoReader(pollInterval time.Duration, maxTrackedSockets int) *NetlinkTCPInfoReader {
+	return &NetlinkTCPInfoReader{
+		pollInterval:      pollInterval,
+		maxTrackedSockets: maxTrackedSockets,
+		out:               make(chan clttypes.TCPInfo, 1000),
+		stopC:             make(chan struct{}),
+	}
+}
+
+func (r *NetlinkTCPInfoReader) TCPInfoChan() <-chan clttypes.TCPInfo {
+	return r.out
+}
+
+func (r *NetlinkTCPInfoReader) Start() {
+	r.wg.Add(1)
+	go r.loop()
+}
+
+func (r *NetlinkTCPInfoReader) Stop() {
+	r.stopCO.Do(func() { close(r.stopC) })
+	r.wg.Wait()
+}
+
+func (r *NetlinkTCPInfoReader) loop() {
+	defer r.wg.Done()
+	ticker := time.NewTicker(r.pollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-r.stopC:
+			return
+		case <-ticker.C:
+			r.poll()
+		}
+	}
+}
+
+func (r *NetlinkTCPInfoReader) poll() {
+	sockets, err := inetdiag.DumpTCPSockets(r.maxTrackedSockets)
+	if err != nil {
+		log.WithError(err).Warn("NetlinkTCPInfoReader: failed to dump TCP sockets via inet_diag")
+		return
+	}
+	for _, sock := range sockets {
+		info := clttypes.TCPInfo{
+			SrcIP:       sock.SrcIP,
+			DstIP:       sock.DstIP,
+			SrcPort:     sock.SrcPort,
+			DstPort:     sock.DstPort,
+			Protocol:    sock.Protocol,
+			SRTT:        sock.TCPInfo.RTT,
+			Retransmits: sock.TCPInfo.TotalRetrans,
+			MinWindow:   sock.TCPInfo.MinWindow,
+			MaxWindow:   sock.TCPInfo.MaxWindow,
+		}
+		select {
+		case r.out <- info:
+		case <-r.stopC:
+			return
+		}
+	}
+}
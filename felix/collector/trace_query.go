@@ -0,0 +1,195 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !windows
+// +build !windows
+
+package collector
+
+import (
+	"sync"
+	"time"
+
+	"github.com/projectcalico/calico/app-policy/policystore"
+	"github.com/projectcalico/calico/felix/calc"
+	"github.com/projectcalico/calico/felix/collector/types/counter"
+	"github.com/projectcalico/calico/felix/collector/types/tuple"
+	"github.com/projectcalico/calico/felix/rules"
+)
+
+// Exposing this over felix's debug HTTP surface and as a calicoctl `trace list` subcommand is
+// follow-up work; neither exists in this tree yet.
+
+// Filter selects which live epStats entries ListActiveTraces returns. A zero-valued field is a
+// wildcard; every non-zero field must match a RuleID on at least one of an entry's
+// IngressPendingRuleIDs/EgressPendingRuleIDs (Since/Until match the entry's most recent update
+// time instead) for the entry to be included. SrcSelector/DstSelector match against the labels
+// calc.LookupsCache has recorded for the tuple's source/destination endpoint, the same labels
+// RuleProvenance.MatchedLabels is drawn from.
+type Filter struct {
+	RuleName        string
+	Tier            string
+	PolicyNamespace string
+	Action          rules.RuleAction
+	Direction       rules.RuleDirection
+	SrcSelector     string
+	DstSelector     string
+	Since           time.Time
+	Until           time.Time
+
+	// Page and PageSize bound how many TraceEntries a single ListActiveTraces call returns; see
+	// Page.NextPage on the result.
+	Page     int
+	PageSize int
+}
+
+// TraceEntry is one epStats entry's resolved identity and counters, as ListActiveTraces and
+// Watch return it.
+type TraceEntry struct {
+	Tuple          tuple.Tuple
+	IngressRuleIDs []*calc.RuleID
+	EgressRuleIDs  []*calc.RuleID
+
+	PacketsIn        counter.Counter
+	PacketsInReverse counter.Counter
+	BytesIn          counter.Counter
+	BytesInReverse   counter.Counter
+}
+
+// Page is one page of a ListActiveTraces call. NextPage is the Filter.Page value to pass to get
+// the following page, or -1 once Entries was the last page.
+type Page struct {
+	Entries  []TraceEntry
+	NextPage int
+}
+
+// ListActiveTraces returns the subset of c's live epStats matching filter, resolved into
+// TraceEntries and paginated per filter.Page/PageSize (a zero PageSize returns every match in one
+// page). It takes the same policyStoreManager read-lock rule_trace_simulator.go's
+// SimulateRuleTrace does, since resolving an endpoint's labels for SrcSelector/DstSelector
+// requires a consistent snapshot of the policy store.
+func (c *collector) ListActiveTraces(filter Filter) Page {
+	var matched []TraceEntry
+	c.policyStoreManager.DoWithLock(func(ps *policystore.PolicyStore) {
+		c.mu.RLock()
+		defer c.mu.RUnlock()
+		for t, data := range c.epStats {
+			ingress := filterRuleIDs(data.IngressPendingRuleIDs, filter)
+			egress := filterRuleIDs(data.EgressPendingRuleIDs, filter)
+			if len(ingress) == 0 && len(egress) == 0 {
+				continue
+			}
+			if !filter.Since.IsZero() && data.LastUpdated().Before(filter.Since) {
+				continue
+			}
+			if !filter.Until.IsZero() && data.LastUpdated().After(filter.Until) {
+				continue
+			}
+			if filter.SrcSelector != "" && !c.lookupsCache.EndpointMatchesSelector(t.Src, filter.SrcSelector, ps) {
+				continue
+			}
+			if filter.DstSelector != "" && !c.lookupsCache.EndpointMatchesSelector(t.Dst, filter.DstSelector, ps) {
+				continue
+			}
+			matched = append(matched, TraceEntry{
+				Tuple:            t,
+				IngressRuleIDs:   ingress,
+				EgressRuleIDs:    egress,
+				PacketsIn:        data.ConntrackPacketsCounter(),
+				PacketsInReverse: data.ConntrackPacketsCounterReverse(),
+				BytesIn:          data.ConntrackBytesCounter(),
+				BytesInReverse:   data.ConntrackBytesCounterReverse(),
+			})
+		}
+	})
+	return paginate(matched, filter.Page, filter.PageSize)
+}
+
+// Watch calls ListActiveTraces(filter) once a second and sends every entry of the returned page,
+// for a "tail -f" view of a specific rule while debugging. An entry is sent again on every pass,
+// whether or not it changed since the previous one. The returned stop function
+// must be called to release the watch once the caller is done; it is safe to call more than
+// once.
+func (c *collector) Watch(filter Filter) (entries <-chan TraceEntry, stop func()) {
+	out := make(chan TraceEntry, 64)
+	done := make(chan struct{})
+	var stopOnce sync.Once
+
+	go func() {
+		ticker := time.NewTicker(time.Second)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				close(out)
+				return
+			case <-ticker.C:
+				for _, entry := range c.ListActiveTraces(filter).Entries {
+					select {
+					case out <- entry:
+					case <-done:
+						close(out)
+						return
+					}
+				}
+			}
+		}
+	}()
+
+	return out, func() { stopOnce.Do(func() { close(done) }) }
+}
+
+// filterRuleIDs returns the subset of ruleIDs matching filter's RuleName/Tier/PolicyNamespace/
+// Action/Direction criteria.
+func filterRuleIDs(ruleIDs []*calc.RuleID, filter Filter) []*calc.RuleID {
+	var out []*calc.RuleID
+	for _, r := range ruleIDs {
+		if filter.RuleName != "" && r.Name != filter.RuleName {
+			continue
+		}
+		if filter.Tier != "" && r.Tier != filter.Tier {
+			continue
+		}
+		if filter.PolicyNamespace != "" && r.Namespace != filter.PolicyNamespace {
+			continue
+		}
+		if filter.Action != 0 && r.Action != filter.Action {
+			continue
+		}
+		if filter.Direction != 0 && r.Direction != filter.Direction {
+			continue
+		}
+		out = append(out, r)
+	}
+	return out
+}
+
+// paginate slices entries per page/pageSize, returning -1 as NextPage once there's no more data.
+// A zero pageSize returns every entry in a single page.
+func paginate(entries []TraceEntry, page, pageSize int) Page {
+	if pageSize <= 0 {
+		return Page{Entries: entries, NextPage: -1}
+	}
+	start := page * pageSize
+	if start >= len(entries) {
+		return Page{NextPage: -1}
+	}
+	end := start + pageSize
+	next := page + 1
+	if end >= len(entries) {
+		end = len(entries)
+		next = -1
+	}
+	return Page{Entries: entries[start:end], NextPage: next}
+}
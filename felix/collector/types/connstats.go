@@ -0,0 +1,49 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "net"
+
+// ConnStatsSnapshot is one per-connection telemetry update, published to a collector.ConnStatsSink
+// after an epStats-equivalent update driven by a NFLOG decision or a conntrack batch. Unlike
+// AuditRecord (one record per packet-filtering decision), a ConnStatsSnapshot is published for
+// every tracked conntrack flow, decision or not, so an external aggregator can build a full
+// per-connection view.
+type ConnStatsSnapshot struct {
+	SrcIP    net.IP
+	DstIP    net.IP
+	SrcPort  int
+	DstPort  int
+	Protocol int
+
+	// SrcEndpoint/DstEndpoint are the resolved workload identities for this flow's 5-tuple, if
+	// known. Once resolved, the collector keeps reporting the last known identity even if a
+	// later update can no longer resolve it (e.g. the endpoint was deleted) -- the identity a
+	// connection was opened with is more useful to an aggregator than a blank field.
+	SrcEndpoint string
+	DstEndpoint string
+
+	// NatOutgoingPort is the post-SNAT source port for outgoing NAT'd connections, or 0 if this
+	// flow wasn't SNAT'd.
+	NatOutgoingPort int
+
+	// TxPackets/TxBytes/RxPackets/RxBytes are deltas since the last snapshot published for this
+	// 5-tuple, not the conntrack entry's cumulative totals -- a sink that wants a running total
+	// sums these itself.
+	TxPackets int
+	TxBytes   int
+	RxPackets int
+	RxBytes   int
+}
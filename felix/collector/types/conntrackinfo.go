@@ -0,0 +1,65 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package types holds the data shapes shared between the collector and the
+// pluggable ConntrackBackend implementations that feed it, so a backend never
+// needs to import the collector package itself.
+package types
+
+import "net"
+
+// ConntrackInfo is one conntrack flow record, as read from whichever
+// collector.ConntrackBackend is configured. It carries enough of the original 5-tuple, NAT
+// translation, and per-direction counters for the collector to update flow stats the same way
+// regardless of whether the entry came from the kernel's conntrack table, Felix's own BPF
+// conntrack map, or a test's in-memory backend.
+type ConntrackInfo struct {
+	// Expired is true when this record reflects an entry that has just aged out, so the
+	// collector should flush and forget the associated flow rather than update it.
+	Expired bool
+
+	// Zone is the conntrack zone the entry belongs to, distinguishing otherwise-identical
+	// tuples tracked in separate zones (e.g. overlapping pod CIDRs across namespaces reached
+	// via MAP-t/NAT64). Event-driven backends dedup by tuple+Zone, not by tuple alone.
+	Zone int
+
+	SrcIP    net.IP
+	DstIP    net.IP
+	SrcPort  int
+	DstPort  int
+	Protocol int
+
+	OrigCounters  Counters
+	ReplyCounters Counters
+
+	// IsDNAT is true if the original destination differs from DstIP, e.g. a packet destined
+	// for a service IP that conntrack (or the BPF conntrack map) has resolved to a backend
+	// pod. PreDNATAddr/PreDNATPort hold the pre-translation destination.
+	IsDNAT      bool
+	PreDNATAddr net.IP
+	PreDNATPort int
+
+	// IsSNAT is true if the original source differs from SrcIP, e.g. outbound traffic that
+	// was masqueraded. PostSNATAddr/PostSNATPort hold the post-translation source.
+	IsSNAT       bool
+	PostSNATAddr net.IP
+	PostSNATPort int
+}
+
+// Counters is a packet/byte pair, reported separately for the original and reply directions of
+// a conntrack entry.
+type Counters struct {
+	Packets int
+	Bytes   int
+}
@@ -0,0 +1,39 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"net"
+	"time"
+)
+
+// TCPInfo is a point-in-time snapshot of a local TCP socket's quality metrics, as read from the
+// kernel's struct tcp_info by a TCPInfoReader and correlated to a flow by its 5-tuple.
+type TCPInfo struct {
+	SrcIP    net.IP
+	DstIP    net.IP
+	SrcPort  int
+	DstPort  int
+	Protocol int
+
+	// SRTT is the smoothed round-trip time estimate (tcpi_rtt).
+	SRTT time.Duration
+	// Retransmits is the cumulative count of retransmitted segments (tcpi_total_retrans).
+	Retransmits int
+	// MinWindow and MaxWindow are the smallest and largest congestion/send windows observed
+	// for this socket since it was last polled.
+	MinWindow uint32
+	MaxWindow uint32
+}
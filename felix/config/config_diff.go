@@ -0,0 +1,107 @@
+// Copyright (c) 2025 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/projectcalico/calico/felix/proto"
+)
+
+// maxConfigDiffHistory bounds the number of resolve() diffs we keep in memory for the
+// /config/history debug endpoint; older entries are dropped on a FIFO basis.
+const maxConfigDiffHistory = 50
+
+// ConfigFieldDiff describes a single field that changed as a result of a resolve(), along with
+// source attribution, so that operators can answer "why did my NftablesMarkMask change" without
+// grepping logs.
+type ConfigFieldDiff struct {
+	Field     string `json:"field"`
+	OldValue  any    `json:"oldValue"`
+	NewValue  any    `json:"newValue"`
+	OldSource Source `json:"oldSource"`
+	NewSource Source `json:"newSource"`
+}
+
+// ConfigDiff is a single resolve()'s worth of field changes.
+type ConfigDiff struct {
+	Changes []ConfigFieldDiff `json:"changes"`
+}
+
+// recordConfigDiff appends diff to the bounded in-memory history, unless it's empty (resolve()
+// with no changes, e.g. a no-op re-resolve).
+func (config *Config) recordConfigDiff(diff ConfigDiff) {
+	if len(diff.Changes) == 0 {
+		return
+	}
+	config.diffHistory = append(config.diffHistory, diff)
+	if excess := len(config.diffHistory) - maxConfigDiffHistory; excess > 0 {
+		config.diffHistory = config.diffHistory[excess:]
+	}
+}
+
+// DiffHistory returns the most recent config diffs, oldest first, capped at
+// maxConfigDiffHistory entries.
+func (config *Config) DiffHistory() []ConfigDiff {
+	out := make([]ConfigDiff, len(config.diffHistory))
+	copy(out, config.diffHistory)
+	return out
+}
+
+// diffToProto converts a ConfigDiff into its protobuf form for piggy-backing on ToConfigUpdate.
+func diffToProto(diff ConfigDiff) *proto.ConfigDiff {
+	if len(diff.Changes) == 0 {
+		return nil
+	}
+	out := &proto.ConfigDiff{}
+	for _, c := range diff.Changes {
+		out.Changes = append(out.Changes, &proto.ConfigFieldDiff{
+			Field:     c.Field,
+			OldValue:  toDiffString(c.OldValue),
+			NewValue:  toDiffString(c.NewValue),
+			OldSource: c.OldSource.String(),
+			NewSource: c.NewSource.String(),
+		})
+	}
+	return out
+}
+
+func toDiffString(v any) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return ""
+	}
+	return string(b)
+}
+
+// RegisterDebugHandlers mounts the /config/history and /config debug endpoints on Felix's debug
+// HTTP server (the same mux the prometheus metrics and health endpoints are served from), so
+// operators can inspect recent config churn and the current per-parameter source breakdown
+// without grepping logs.
+func (config *Config) RegisterDebugHandlers(mux *http.ServeMux) {
+	mux.HandleFunc("/config/history", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(config.DiffHistory()); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+	mux.HandleFunc("/config", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(config.SourceBreakdown()); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+}
@@ -66,6 +66,7 @@ const (
 	DatastoreGlobal
 	DatastorePerHost
 	ConfigFile
+	KubernetesConfigMap
 	EnvironmentVariable
 	InternalOverride
 )
@@ -78,7 +79,7 @@ const (
 	DefaultConntrackPollingInterval = time.Duration(5) * time.Second
 )
 
-var SourcesInDescendingOrder = []Source{InternalOverride, EnvironmentVariable, ConfigFile, DatastorePerHost, DatastoreGlobal}
+var SourcesInDescendingOrder = []Source{InternalOverride, EnvironmentVariable, KubernetesConfigMap, ConfigFile, DatastorePerHost, DatastoreGlobal}
 
 func (source Source) String() string {
 	switch source {
@@ -90,6 +91,8 @@ func (source Source) String() string {
 		return "datastore (per-host)"
 	case ConfigFile:
 		return "config file"
+	case KubernetesConfigMap:
+		return "Kubernetes ConfigMap/Secret"
 	case EnvironmentVariable:
 		return "environment variable"
 	case InternalOverride:
@@ -182,47 +185,57 @@ type Config struct {
 	// nftables configuration.
 	NFTablesMode string `config:"oneof(Enabled,Disabled);Disabled"`
 
+	// NetworkManager integration.  When enabled, Felix keeps a conf.d drop-in file in sync so that
+	// NetworkManager leaves Calico-owned interfaces alone instead of racing Felix to configure them.
+	NetworkManagerConfigMode             string           `config:"oneof(Auto,Enabled,Disabled);Auto"`
+	NetworkManagerConfFile               string           `config:"file;/etc/NetworkManager/conf.d/calico.conf"`
+	NetworkManagerExtraInterfacePatterns []*regexp.Regexp `config:"iface-list-regexp;"`
+
 	// BPF configuration.
 	BPFEnabled                         bool              `config:"bool;false"`
 	BPFDisableUnprivileged             bool              `config:"bool;true"`
-	BPFLogLevel                        string            `config:"oneof(off,info,debug);off;non-zero"`
+	BPFLogLevel                        string            `config:"oneof(off,info,debug);off;non-zero" reload:"live"`
 	BPFConntrackLogLevel               string            `config:"oneof(off,debug);off;non-zero"`
 	BPFConntrackCleanupMode            string            `config:"oneof(Auto,Userspace,BPFProgram);Auto"`
 	BPFConntrackTimeouts               map[string]string `config:"keyvaluelist;CreationGracePeriod=10s,TCPSynSent=20s,TCPEstablished=1h,TCPFinsSeen=Auto,TCPResetSeen=40s,UDPTimeout=60s,GenericTimeout=10m,ICMPTimeout=5s"`
 	BPFLogFilters                      map[string]string `config:"keyvaluelist;;"`
-	BPFCTLBLogFilter                   string            `config:"oneof(all);;"`
+	BPFCTLBLogFilter                   string            `config:"ctlb-log-filter;all;"`
 	BPFDataIfacePattern                *regexp.Regexp    `config:"regexp;^((en|wl|ww|sl|ib)[Popsx].*|(eth|wlan|wwan|bond).*)"`
 	BPFL3IfacePattern                  *regexp.Regexp    `config:"regexp;"`
 	BPFConnectTimeLoadBalancingEnabled bool              `config:"bool;;"`
 	BPFConnectTimeLoadBalancing        string            `config:"oneof(TCP,Enabled,Disabled);TCP;non-zero"`
 	BPFHostNetworkedNATWithoutCTLB     string            `config:"oneof(Enabled,Disabled);Enabled;non-zero"`
-	BPFExternalServiceMode             string            `config:"oneof(tunnel,dsr);tunnel;non-zero"`
-	BPFDSROptoutCIDRs                  []string          `config:"cidr-list;;"`
-	BPFKubeProxyIptablesCleanupEnabled bool              `config:"bool;true"`
-	BPFKubeProxyMinSyncPeriod          time.Duration     `config:"seconds;1"`
-	BPFKubeProxyEndpointSlicesEnabled  bool              `config:"bool;true"`
-	BPFExtToServiceConnmark            int               `config:"int;0"`
-	BPFPSNATPorts                      numorstring.Port  `config:"portrange;20000:29999"`
-	BPFMapSizeNATFrontend              int               `config:"int;65536;non-zero"`
-	BPFMapSizeNATBackend               int               `config:"int;262144;non-zero"`
-	BPFMapSizeNATAffinity              int               `config:"int;65536;non-zero"`
-	BPFMapSizeRoute                    int               `config:"int;262144;non-zero"`
-	BPFMapSizeConntrack                int               `config:"int;512000;non-zero"`
-	BPFMapSizePerCPUConntrack          int               `config:"int;0"`
-	BPFMapSizeConntrackScaling         string            `config:"oneof(Disabled,DoubleIfFull);DoubleIfFull;non-zero"`
-	BPFMapSizeConntrackCleanupQueue    int               `config:"int;100000;non-zero"`
-	BPFMapSizeIPSets                   int               `config:"int;1048576;non-zero"`
-	BPFMapSizeIfState                  int               `config:"int;1000;non-zero"`
-	BPFHostConntrackBypass             bool              `config:"bool;false"`
-	BPFEnforceRPF                      string            `config:"oneof(Disabled,Strict,Loose);Loose;non-zero"`
-	BPFPolicyDebugEnabled              bool              `config:"bool;true"`
-	BPFForceTrackPacketsFromIfaces     []string          `config:"iface-filter-slice;docker+"`
-	BPFDisableGROForIfaces             *regexp.Regexp    `config:"regexp;"`
-	BPFExcludeCIDRsFromNAT             []string          `config:"cidr-list;;"`
-	BPFRedirectToPeer                  string            `config:"oneof(Disabled,Enabled,L2Only);L2Only;non-zero"`
-	BPFAttachType                      string            `config:"oneof(tcx,tc);tcx;non-zero"`
-	BPFExportBufferSizeMB              int               `config:"int;1;non-zero"`
-	BPFProfiling                       string            `config:"oneof(Disabled,Enabled);Disabled;non-zero"`
+	// BPFCTLBWorkaround controls the from-host NAT program that lets host-networked processes
+	// (and, critically, UDP DNS clients) reach a live service backend when the connect-time load
+	// balancer is disabled, or TCP-only, for the relevant protocol.
+	BPFCTLBWorkaround                  string           `config:"oneof(Disabled,UDPOnly,Enabled);UDPOnly;non-zero"`
+	BPFExternalServiceMode             string           `config:"oneof(tunnel,dsr);tunnel;non-zero"`
+	BPFDSROptoutCIDRs                  []string         `config:"cidr-list;;"`
+	BPFKubeProxyIptablesCleanupEnabled bool             `config:"bool;true"`
+	BPFKubeProxyMinSyncPeriod          time.Duration    `config:"seconds;1"`
+	BPFKubeProxyEndpointSlicesEnabled  bool             `config:"bool;true"`
+	BPFExtToServiceConnmark            int              `config:"int;0"`
+	BPFPSNATPorts                      numorstring.Port `config:"portrange;20000:29999"`
+	BPFMapSizeNATFrontend              int              `config:"int;65536;non-zero"`
+	BPFMapSizeNATBackend               int              `config:"int;262144;non-zero"`
+	BPFMapSizeNATAffinity              int              `config:"int;65536;non-zero"`
+	BPFMapSizeRoute                    int              `config:"int;262144;non-zero"`
+	BPFMapSizeConntrack                int              `config:"int;512000;non-zero"`
+	BPFMapSizePerCPUConntrack          int              `config:"int;0"`
+	BPFMapSizeConntrackScaling         string           `config:"oneof(Disabled,DoubleIfFull);DoubleIfFull;non-zero"`
+	BPFMapSizeConntrackCleanupQueue    int              `config:"int;100000;non-zero"`
+	BPFMapSizeIPSets                   int              `config:"int;1048576;non-zero"`
+	BPFMapSizeIfState                  int              `config:"int;1000;non-zero"`
+	BPFHostConntrackBypass             bool             `config:"bool;false"`
+	BPFEnforceRPF                      string           `config:"oneof(Disabled,Strict,Loose);Loose;non-zero"`
+	BPFPolicyDebugEnabled              bool             `config:"bool;true"`
+	BPFForceTrackPacketsFromIfaces     []string         `config:"iface-filter-slice;docker+"`
+	BPFDisableGROForIfaces             *regexp.Regexp   `config:"regexp;"`
+	BPFExcludeCIDRsFromNAT             []string         `config:"cidr-list;;"`
+	BPFRedirectToPeer                  string           `config:"oneof(Disabled,Enabled,L2Only);L2Only;non-zero"`
+	BPFAttachType                      string           `config:"oneof(tcx,tc);tcx;non-zero"`
+	BPFExportBufferSizeMB              int              `config:"int;1;non-zero"`
+	BPFProfiling                       string           `config:"oneof(Disabled,Enabled);Disabled;non-zero"`
 
 	// DebugBPFCgroupV2 controls the cgroup v2 path that we apply the connect-time load balancer to.  Most distros
 	// are configured for cgroup v1, which prevents all but the root cgroup v2 from working so this is only useful
@@ -249,7 +262,7 @@ type Config struct {
 	EtcdScheme string `config:"oneof(http,https);http;local"`
 	// EtcdKeyFile: when using the `etcdv3` datastore driver, path to TLS private key file to use when connecting to
 	// etcd.  If the key file is specified, the other TLS parameters are mandatory.
-	EtcdKeyFile string `config:"file(must-exist);;local"`
+	EtcdKeyFile string `config:"file(must-exist);;local,sensitive"`
 	// EtcdCertFile: when using the `etcdv3` datastore driver, path to TLS certificate file to use when connecting to
 	// etcd.  If the certificate file is specified, the other TLS parameters are mandatory.
 	EtcdCertFile string `config:"file(must-exist);;local"`
@@ -275,7 +288,7 @@ type Config struct {
 
 	// TyphaKeyFile path to the TLS private key to use when communicating with Typha.  If this parameter is specified,
 	// the other TLS parameters must also be specified.
-	TyphaKeyFile string `config:"file(must-exist);;local"`
+	TyphaKeyFile string `config:"file(must-exist);;local,sensitive"`
 	// TyphaCertFile path to the TLS certificate to use when communicating with Typha.  If this parameter is specified,
 	// the other TLS parameters must also be specified.
 	TyphaCertFile string `config:"file(must-exist);;local"`
@@ -300,7 +313,7 @@ type Config struct {
 	RemoveExternalRoutes               bool              `config:"bool;true"`
 	ProgramClusterRoutes               string            `config:"oneof(Enabled,Disabled);Disabled"`
 	IPForwarding                       string            `config:"oneof(Enabled,Disabled);Enabled"`
-	IptablesRefreshInterval            time.Duration     `config:"seconds;180"`
+	IptablesRefreshInterval            time.Duration     `config:"seconds;180" reload:"live"`
 	IptablesPostWriteCheckIntervalSecs time.Duration     `config:"seconds;5"`
 	IptablesLockFilePath               string            `config:"file;/run/xtables.lock"`
 	IptablesLockTimeoutSecs            time.Duration     `config:"seconds;0"`
@@ -333,7 +346,7 @@ type Config struct {
 	LogFilePath string `config:"file;/var/log/calico/felix.log;die-on-fail"`
 
 	LogSeverityFile   string `config:"oneof(TRACE,DEBUG,INFO,WARNING,ERROR,FATAL);INFO"`
-	LogSeverityScreen string `config:"oneof(TRACE,DEBUG,INFO,WARNING,ERROR,FATAL);INFO"`
+	LogSeverityScreen string `config:"oneof(TRACE,DEBUG,INFO,WARNING,ERROR,FATAL);INFO" reload:"live"`
 	LogSeveritySys    string `config:"oneof(TRACE,DEBUG,INFO,WARNING,ERROR,FATAL);INFO"`
 	// LogDebugFilenameRegex controls which source code files have their Debug log output included in the logs.
 	// Only logs from files with names that match the given regular expression are included.  The filter only applies
@@ -398,7 +411,7 @@ type Config struct {
 	HealthHost             string                   `config:"host-address;localhost"`
 	HealthTimeoutOverrides map[string]time.Duration `config:"keydurationlist;;"`
 
-	PrometheusMetricsEnabled          bool   `config:"bool;false"`
+	PrometheusMetricsEnabled          bool   `config:"bool;false" reload:"live"`
 	PrometheusMetricsHost             string `config:"host-address;"`
 	PrometheusMetricsPort             int    `config:"int(0:65535);9091"`
 	PrometheusGoMetricsEnabled        bool   `config:"bool;true"`
@@ -414,6 +427,69 @@ type Config struct {
 	FlowLogsLocalReporter        string        `config:"oneof(Enabled,Disabled);Disabled"`
 	FlowLogsPolicyEvaluationMode string        `config:"oneof(None,Continuous);Continuous"`
 
+	// RuleIDHashFields lists the comma-separated calc.RuleID fields (Tier, Name, Namespace,
+	// Action, Direction, Index) that calc.RuleID.CanonicalID hashes together into the
+	// identifier the collector uses to dedupe flow logs for "the same rule" across a Felix
+	// restart or a policy re-sync. Leave unset to use calc.DefaultRuleIDHashFields; trade off
+	// cardinality against specificity by naming fewer fields (coarser, more stable identity) or
+	// more (finer, more likely to flag a re-sync as a new rule). No collector code reads this
+	// field; the collector package does not import felix/config.
+	RuleIDHashFields string `config:"string;Tier,Name,Namespace,Action,Direction,Index"`
+
+	// ConntrackInfoBackend selects which collector.ConntrackBackend the flow/audit log
+	// pipeline reads conntrack entries from: Netlink polls the kernel's conntrack table, and
+	// is the only option that works with the iptables dataplane; Event subscribes to the
+	// kernel's conntrack multicast groups for sub-second visibility into short-lived flows,
+	// at the cost of an additional open netlink socket; BPF scans Felix's own BPF conntrack
+	// map, and is required for the eBPF dataplane since it has no kernel conntrack table to
+	// read. Nothing outside felix/config reads this field, so the collector does not pick its
+	// backend from it.
+	ConntrackInfoBackend string `config:"oneof(Netlink,Event,BPF);Netlink"`
+
+	// AuditLog* configure the packet-level allow/deny audit log described in AuditLogger.
+	// Unlike the flow logs above (which report aggregated flow stats), the audit log emits one
+	// structured record per NFLOG-observed packet-filtering decision, intended for security
+	// review rather than capacity planning. AuditLogger takes its settings as constructor
+	// arguments; nothing outside felix/config reads these fields.
+	AuditLogEnabled               bool          `config:"bool;false"`
+	AuditLogFilePath              string        `config:"file;/var/log/calico/audit/allow-deny.log"`
+	AuditLogFileMaxSizeMB         int           `config:"int(1:);100"`
+	AuditLogFileMaxAgeDays        int           `config:"int(0:);30"`
+	AuditLogFileMaxBackups        int           `config:"int(0:);10"`
+	AuditLogSyslogNetwork         string        `config:"oneof(,tcp,udp);"`
+	AuditLogSyslogAddress         string        `config:"string;"`
+	AuditLogSampleRate            float64       `config:"float(0:1);1.0"`
+	AuditLogRateLimitPerSecond    int           `config:"int(0:);1000"`
+	AuditLogAggregationWindowSecs time.Duration `config:"seconds;0"`
+
+	// AuditLogTCPStats* control the optional TCPInfoReader that enriches audit records with
+	// per-connection TCP quality metrics (RTT, retransmits, window). Polling is disabled
+	// (AuditLogTCPStatsPollIntervalSecs is 0) by default, since it costs an inet_diag netlink
+	// dump per interval; MaxTrackedSockets bounds that cost on hosts with many connections.
+	// Nothing outside felix/config reads these fields.
+	AuditLogTCPStatsPollIntervalSecs  time.Duration `config:"seconds;0"`
+	AuditLogTCPStatsMaxTrackedSockets int           `config:"int(0:);10000"`
+
+	// PolicyCountersMaxLabelCombinations caps the number of distinct (tier, policy, action,
+	// direction, namespace) combinations the calico_policy_packets_total/calico_policy_bytes_total
+	// Prometheus counters will track before folding further combinations into a shared
+	// overflow bucket; see felix/collector/metrics.PolicyCounters. Nothing outside felix/config
+	// reads this field.
+	PolicyCountersMaxLabelCombinations int `config:"int(0:);10000"`
+
+	// IPFIX* describe the optional IPFIXExporter, which ships enriched audit records to
+	// external IPFIX collectors alongside (not instead of) the AuditLog* sink. Nothing outside
+	// felix/config reads these fields; an IPFIXExporter is attached with
+	// AuditLogger.SetIPFIXExporter.
+	IPFIXTargets             []string      `config:"endpoint-list;;"`
+	IPFIXTemplateRefreshSecs time.Duration `config:"seconds;600"`
+	IPFIXObservationDomainID int           `config:"int(0:4294967295);0"`
+
+	// ConnStatsStreamSocketPath names a Unix domain socket for collector.StreamConnStatsSink,
+	// which streams one ConnStatsSnapshot per connection update. Nothing outside felix/config
+	// reads this field; NewStreamConnStatsSink takes the path as its argument.
+	ConnStatsStreamSocketPath string `config:"file;;"`
+
 	KubeNodePortRanges    []numorstring.Port `config:"portrange-list;30000:32767"`
 	NATPortRange          numorstring.Port   `config:"portrange;"`
 	NATOutgoingAddress    net.IP             `config:"ipv4;"`
@@ -447,7 +523,7 @@ type Config struct {
 	RouteSource string `config:"oneof(WorkloadIPs,CalicoIPAM);CalicoIPAM"`
 
 	// RouteTableRange is deprecated in favor of RouteTableRanges,
-	RouteTableRange   idalloc.IndexRange   `config:"route-table-range;;die-on-fail"`
+	RouteTableRange   idalloc.IndexRange   `config:"route-table-range;;die-on-fail" deprecated:"3.28,replaced-by=RouteTableRanges"`
 	RouteTableRanges  []idalloc.IndexRange `config:"route-table-ranges;;die-on-fail"`
 	RouteSyncDisabled bool                 `config:"bool;false"`
 
@@ -468,6 +544,10 @@ type Config struct {
 	// GoMaxProcs sets the Go runtime's GOMAXPROCS.  It is overridden by the GOMAXPROCS env var if that is also
 	// set. A value of -1 disables the override and uses the runtime default.
 	GoMaxProcs int `config:"int(-1);-1"`
+	// GoRuntimeAutoTune enables periodic, cgroup-aware tuning of the Go runtime: GOGC, GOMEMLIMIT and
+	// GOMAXPROCS are derived from the container's memory/cpu cgroup limits (and, on cgroup v2, PSI
+	// memory-pressure events) instead of the static GoGCThreshold/GoMemoryLimitMB/GoMaxProcs values above.
+	GoRuntimeAutoTune bool `config:"bool;false"`
 
 	// Configures MTU auto-detection.
 	MTUIfacePattern *regexp.Regexp `config:"regexp;^((en|wl|ww|sl|ib)[Pcopsvx].*|(eth|wlan|wwan).*)"`
@@ -496,6 +576,25 @@ type Config struct {
 	sourceToRawConfig map[Source]map[string]string
 	// rawValues maps keys to the current highest-priority raw value.
 	rawValues map[string]string
+	// environmentOverrides records the names (never the values, to avoid leaking secrets such as
+	// EtcdKeyFile) of the fields most recently set from os.Environ() by ScanEnvironmentOverrides.
+	environmentOverrides []string
+	// fieldSources records, for every config field, the Source that won on the most recent
+	// resolve() (Default where no source supplied a value).  Used to compute the previous-source
+	// entry in ConfigDiff on the next resolve.
+	fieldSources map[string]Source
+	// diffHistory holds the most recent config diffs, most recent last, capped at
+	// maxConfigDiffHistory entries.  Exposed via the /config/history debug endpoint;
+	// ToConfigUpdate carries only the most recent entry.
+	diffHistory []ConfigDiff
+	// reloadCallbacks are invoked, each with the raw values of the live-reloadable fields that
+	// just changed, whenever a ConfigWatcher (or any other future live-reload source) applies an
+	// update in place rather than requiring a restart.  See OnReload.
+	reloadCallbacks []func(changed map[string]string)
+	// deprecationWarned tracks, per deprecated field name and source, whether warnIfDeprecated
+	// has already logged/alerted for that pair, so a deprecated value re-applied on every
+	// resolve() (e.g. from a long-lived ConfigFile source) doesn't spam the logs.
+	deprecationWarned map[string]map[Source]bool
 	// Err holds the most recent error from a config update.
 	Err error
 
@@ -554,6 +653,25 @@ func (config *Config) ProgramClusterRoutesEnabled() bool {
 	return config.ProgramClusterRoutes == "Enabled"
 }
 
+// applyDeprecatedFeatureGates honors FeatureGates entries that have been promoted to first-class
+// Config fields, so upgrades that still set the old feature gate keep working.  If the field has
+// also been set explicitly, the explicit value wins; the feature gate is only consulted as a
+// fallback.
+func (config *Config) applyDeprecatedFeatureGates() {
+	if !config.setByConfigFileOrEnvironment("BPFCTLBWorkaround") {
+		if v, ok := config.FeatureGates["BPFConnectTimeLoadBalancingWorkaround"]; ok {
+			log.WithField("value", v).Warn(
+				"FeatureGates[BPFConnectTimeLoadBalancingWorkaround] is deprecated; " +
+					"use BPFCTLBWorkaround instead.")
+			if strings.EqualFold(v, "true") || strings.EqualFold(v, "Enabled") {
+				config.BPFCTLBWorkaround = "Enabled"
+			} else if strings.EqualFold(v, "false") || strings.EqualFold(v, "Disabled") {
+				config.BPFCTLBWorkaround = "Disabled"
+			}
+		}
+	}
+}
+
 // Copy makes a copy of the object.  Internal state is deep copied but config parameters are only shallow copied.
 // This saves work since updates to the copy will trigger the config params to be recalculated.
 func (config *Config) Copy() *Config {
@@ -579,6 +697,13 @@ func (config *Config) Copy() *Config {
 		cp.rawValues[k] = v
 	}
 
+	cp.fieldSources = map[string]Source{}
+	for k, v := range config.fieldSources {
+		cp.fieldSources[k] = v
+	}
+
+	cp.diffHistory = append([]ConfigDiff(nil), config.diffHistory...)
+
 	return &cp
 }
 
@@ -611,6 +736,10 @@ func (config *Config) ToConfigUpdate() *proto.ConfigUpdate {
 		buf.Config[k] = v
 	}
 
+	if len(config.diffHistory) > 0 {
+		buf.ConfigDiff = diffToProto(config.diffHistory[len(config.diffHistory)-1])
+	}
+
 	return &buf
 }
 
@@ -647,6 +776,9 @@ func (config *Config) UpdateFrom(rawData map[string]string, source Source) (chan
 			continue
 		}
 		rawDataCopy[k] = v
+		if param, ok := knownParams[strings.ToLower(k)]; ok {
+			config.warnIfDeprecated(param.GetMetadata(), source)
+		}
 	}
 	config.sourceToRawConfig[source] = rawDataCopy
 
@@ -815,7 +947,11 @@ func (config *Config) resolve() (changedFields set.Set[string], err error) {
 		}
 	}
 
+	config.applyDeprecatedFeatureGates()
+
 	changedFields = set.New[string]()
+	var diff ConfigDiff
+	newFieldSources := map[string]Source{}
 	kind := reflect.TypeOf(Config{})
 	for ii := 0; ii < kind.NumField(); ii++ {
 		field := kind.Field(ii)
@@ -824,6 +960,12 @@ func (config *Config) resolve() (changedFields set.Set[string], err error) {
 			continue
 		}
 
+		newSource := Default
+		if s, ok := nameToSource[strings.ToLower(field.Name)]; ok {
+			newSource = s
+		}
+		newFieldSources[field.Name] = newSource
+
 		oldV := reflect.ValueOf(oldConfigCopy).Elem().Field(ii).Interface()
 		newV := reflect.ValueOf(config).Elem().Field(ii).Interface()
 
@@ -831,10 +973,30 @@ func (config *Config) resolve() (changedFields set.Set[string], err error) {
 			continue
 		}
 		changedFields.Add(field.Name)
+		diff.Changes = append(diff.Changes, ConfigFieldDiff{
+			Field:     field.Name,
+			OldValue:  oldV,
+			NewValue:  newV,
+			OldSource: config.fieldSources[field.Name],
+			NewSource: newSource,
+		})
 	}
 	log.WithField("changedFields", changedFields).Debug("Calculated changed fields.")
 
 	config.rawValues = newRawValues
+	config.fieldSources = newFieldSources
+	config.recordConfigDiff(diff)
+
+	if failures := runValidators(config); len(failures) > 0 {
+		for _, failure := range failures {
+			log.WithError(failure.err).WithField("fields", failure.fields).Warn("Config validator failed.")
+			for _, f := range failure.fields {
+				changedFields.Add(f)
+			}
+		}
+		config.Err = failures[0].err
+	}
+
 	return
 }
 
@@ -886,6 +1048,12 @@ func (config *Config) setByConfigFileOrEnvironment(name string) bool {
 	return config.setBy(name, ConfigFile) || config.setBy(name, EnvironmentVariable)
 }
 
+// setByKubernetesConfigMap reports whether the named parameter's current value came from a
+// watched ConfigMap/Secret (see KubernetesConfigMapWatcher).
+func (config *Config) setByKubernetesConfigMap(name string) bool {
+	return config.setBy(name, KubernetesConfigMap)
+}
+
 func (config *Config) DatastoreConfig() apiconfig.CalicoAPIConfig {
 	// We want Felix's datastore connection to be fully configurable using the same
 	// CALICO_XXX_YYY (or just XXX_YYY) environment variables that work for any libcalico-go
@@ -984,6 +1152,12 @@ func (config *Config) Validate() (err error) {
 		}
 	}
 
+	if err == nil {
+		if failures := runValidators(config); len(failures) > 0 {
+			err = failures[0].err
+		}
+	}
+
 	if err != nil {
 		config.Err = err
 	}
@@ -1156,6 +1330,8 @@ func loadParams() {
 			param = &KeyValueListParam{}
 		case "keydurationlist":
 			param = &KeyDurationListParam{}
+		case "ctlb-log-filter":
+			param = &CTLBLogFilterParam{}
 		default:
 			log.Panicf("Unknown type of parameter: %v", kind)
 			panic("Unknown type of parameter") // Unreachable, keep the linter happy.
@@ -1174,6 +1350,34 @@ func loadParams() {
 		if strings.Contains(flags, "local") {
 			metadata.Local = true
 		}
+		if strings.Contains(flags, "sensitive") {
+			metadata.Sensitive = true
+		}
+		metadata.ReloadPolicy = ReloadRestart
+		if reloadTag := field.Tag.Get("reload"); reloadTag == "live" {
+			metadata.ReloadPolicy = ReloadLive
+		}
+		if validateTag := field.Tag.Get("validate"); validateTag != "" {
+			for _, clause := range strings.Split(validateTag, ";") {
+				if name, value, ok := strings.Cut(clause, "="); ok && name == "requires" {
+					metadata.RequiresFields = strings.Split(value, ",")
+				}
+			}
+		}
+		if deprecatedTag := field.Tag.Get("deprecated"); deprecatedTag != "" {
+			for i, clause := range strings.Split(deprecatedTag, ",") {
+				if name, value, ok := strings.Cut(clause, "="); ok {
+					switch name {
+					case "replaced-by":
+						metadata.ReplacedBy = value
+					case "removed-in":
+						metadata.RemovedIn = value
+					}
+				} else if i == 0 {
+					metadata.DeprecatedSince = clause
+				}
+			}
+		}
 
 		if defaultStr != "" {
 			metadata.DefaultString = defaultStr
@@ -1266,9 +1470,14 @@ func New() *Config {
 		rawValues:         map[string]string{},
 		sourceToRawConfig: map[Source]map[string]string{},
 		internalOverrides: map[string]string{},
+		fieldSources:      map[string]Source{},
+		deprecationWarned: map[string]map[Source]bool{},
 	}
 	p.loadClientConfigFromEnvironment = apiconfig.LoadClientConfigFromEnvironment
 	p.applyDefaults()
+	if _, err := p.ScanEnvironmentOverrides(); err != nil {
+		log.WithError(err).Warn("Failed to apply FELIX_-prefixed environment variable overrides.")
+	}
 
 	return p
 }
@@ -0,0 +1,158 @@
+// Copyright (c) 2025 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"net"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/projectcalico/api/pkg/lib/numorstring"
+)
+
+// CTLBLogFilter is the parsed form of BPFCTLBLogFilter: a mini-language for selecting which
+// connect-time load balancer events the BPF-side logger should emit, e.g.
+// "proto=udp,dport=53" or "cgroup=/kubepods.slice/*,saddr=10.0.0.0/8".  An empty CTLBLogFilter
+// (the zero value, also produced by the "all" alias) matches everything.
+type CTLBLogFilter struct {
+	Protocol   string
+	SrcCIDR    *net.IPNet
+	DstCIDR    *net.IPNet
+	SrcPorts   *numorstring.Port
+	DstPorts   *numorstring.Port
+	CgroupGlob string
+}
+
+// Matches reports whether the filter selects a connection with the given attributes.  Fields
+// left unset on the filter are treated as wildcards.
+func (f CTLBLogFilter) Matches(proto string, src, dst net.IP, sport, dport uint16, cgroup string) bool {
+	if f.Protocol != "" && !strings.EqualFold(f.Protocol, proto) {
+		return false
+	}
+	if f.SrcCIDR != nil && !f.SrcCIDR.Contains(src) {
+		return false
+	}
+	if f.DstCIDR != nil && !f.DstCIDR.Contains(dst) {
+		return false
+	}
+	if f.SrcPorts != nil && !portInRange(*f.SrcPorts, sport) {
+		return false
+	}
+	if f.DstPorts != nil && !portInRange(*f.DstPorts, dport) {
+		return false
+	}
+	if f.CgroupGlob != "" {
+		if ok, err := filepath.Match(f.CgroupGlob, cgroup); err != nil || !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func portInRange(pr numorstring.Port, port uint16) bool {
+	return port >= pr.MinPort && port <= pr.MaxPort
+}
+
+// ParseCTLBLogFilter parses the BPFCTLBLogFilter mini-language.  "all" (and the empty string) is
+// kept as an alias for the zero-value filter, for backward compatibility with the old
+// oneof(all) field.
+func ParseCTLBLogFilter(raw string) (CTLBLogFilter, error) {
+	var f CTLBLogFilter
+	if raw == "" || strings.EqualFold(raw, "all") {
+		return f, nil
+	}
+	for _, clause := range strings.Split(raw, ",") {
+		clause = strings.TrimSpace(clause)
+		if clause == "" {
+			continue
+		}
+		kv := strings.SplitN(clause, "=", 2)
+		if len(kv) != 2 {
+			return f, fmt.Errorf("invalid CTLB log filter clause %q: expected key=value", clause)
+		}
+		key, value := strings.ToLower(kv[0]), kv[1]
+		switch key {
+		case "proto":
+			f.Protocol = value
+		case "saddr":
+			_, cidr, err := net.ParseCIDR(value)
+			if err != nil {
+				return f, fmt.Errorf("invalid saddr %q: %w", value, err)
+			}
+			f.SrcCIDR = cidr
+		case "daddr":
+			_, cidr, err := net.ParseCIDR(value)
+			if err != nil {
+				return f, fmt.Errorf("invalid daddr %q: %w", value, err)
+			}
+			f.DstCIDR = cidr
+		case "sport":
+			pr, err := parsePortValue(value)
+			if err != nil {
+				return f, fmt.Errorf("invalid sport %q: %w", value, err)
+			}
+			f.SrcPorts = &pr
+		case "dport":
+			pr, err := parsePortValue(value)
+			if err != nil {
+				return f, fmt.Errorf("invalid dport %q: %w", value, err)
+			}
+			f.DstPorts = &pr
+		case "cgroup":
+			f.CgroupGlob = value
+		default:
+			return f, fmt.Errorf("unknown CTLB log filter key %q", key)
+		}
+	}
+	return f, nil
+}
+
+func parsePortValue(value string) (numorstring.Port, error) {
+	port, err := strconv.Atoi(value)
+	if err != nil {
+		return numorstring.Port{}, err
+	}
+	return numorstring.SinglePort(uint16(port)), nil
+}
+
+// CTLBLogFilterParam is the Param implementation backing the "ctlb-log-filter" config kind: it
+// validates the filter mini-language at load time but stores the raw string on Config so the
+// BPF-side loader can re-parse and compile it into a map-backed predicate.
+type CTLBLogFilterParam struct {
+	Metadata
+}
+
+func (p *CTLBLogFilterParam) GetMetadata() *Metadata {
+	return &p.Metadata
+}
+
+func (p *CTLBLogFilterParam) Parse(raw string) (interface{}, error) {
+	if _, err := ParseCTLBLogFilter(raw); err != nil {
+		return nil, err
+	}
+	return raw, nil
+}
+
+func (p *CTLBLogFilterParam) setDefault(config *Config) {
+	field := reflect.ValueOf(config).Elem().FieldByName(p.GetMetadata().Name)
+	field.Set(reflect.ValueOf(p.Metadata.Default))
+}
+
+func (p *CTLBLogFilterParam) SchemaDescription() string {
+	return "Comma-delimited CTLB log filter expression, e.g. `proto=udp,dport=53`, or `all` to log everything."
+}
@@ -0,0 +1,63 @@
+// Copyright (c) 2025 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+)
+
+// gaugeDeprecatedParamInUse gives operators a scrapeable upgrade-readiness signal for the
+// deprecated config surface, rather than requiring them to grep Felix's logs for deprecation
+// warnings across every node.
+var gaugeDeprecatedParamInUse = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "felix_config_deprecated_param",
+	Help: "Set to 1 for each (deprecated parameter, source) pair that is currently supplying a value.",
+}, []string{"name", "source"})
+
+func init() {
+	prometheus.MustRegister(gaugeDeprecatedParamInUse)
+}
+
+// warnIfDeprecated logs a structured warning and sets gaugeDeprecatedParamInUse, the first time
+// (and only the first time) a value for a deprecated param is observed from a given source.
+// RouteTableIndices still logs its own warning when it falls back to RouteTableRange.
+func (config *Config) warnIfDeprecated(metadata *Metadata, source Source) {
+	if metadata.DeprecatedSince == "" {
+		return
+	}
+	if config.deprecationWarned[metadata.Name] == nil {
+		config.deprecationWarned[metadata.Name] = map[Source]bool{}
+	}
+	if config.deprecationWarned[metadata.Name][source] {
+		return
+	}
+	config.deprecationWarned[metadata.Name][source] = true
+
+	logCxt := log.WithFields(log.Fields{
+		"name":            metadata.Name,
+		"source":          source,
+		"deprecatedSince": metadata.DeprecatedSince,
+	})
+	if metadata.ReplacedBy != "" {
+		logCxt = logCxt.WithField("replacedBy", metadata.ReplacedBy)
+	}
+	if metadata.RemovedIn != "" {
+		logCxt = logCxt.WithField("removedIn", metadata.RemovedIn)
+	}
+	logCxt.Warn("Configuration was set using a deprecated parameter.")
+
+	gaugeDeprecatedParamInUse.WithLabelValues(metadata.Name, source.String()).Set(1)
+}
@@ -0,0 +1,66 @@
+// Copyright (c) 2025 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"os"
+	"sort"
+	"strings"
+)
+
+// environmentVariablePrefix is stripped (case-insensitively) from os.Environ() entries before
+// the remainder is lower-cased and looked up in knownParams.
+const environmentVariablePrefix = "FELIX_"
+
+// ScanEnvironmentOverrides scans os.Environ() for FELIX_-prefixed variables (e.g.
+// FELIX_LOGSEVERITYSCREEN=Debug, FELIX_ROUTETABLERANGES=1-250,300-400), parses each one through
+// the same Param.Parse machinery used for datastore/config-file values, and applies the
+// recognised ones at the EnvironmentVariable source level.  Unknown FELIX_-prefixed variables are
+// ignored (they may be destined for some other consumer, e.g. a wrapper script).
+func (config *Config) ScanEnvironmentOverrides() (changed bool, err error) {
+	raw := map[string]string{}
+	for _, kv := range os.Environ() {
+		name, value, ok := strings.Cut(kv, "=")
+		if !ok {
+			continue
+		}
+		if !strings.HasPrefix(strings.ToUpper(name), environmentVariablePrefix) {
+			continue
+		}
+		fieldName := strings.ToLower(strings.TrimPrefix(strings.ToUpper(name), environmentVariablePrefix))
+		if _, ok := knownParams[fieldName]; !ok {
+			continue
+		}
+		raw[fieldName] = value
+	}
+
+	applied := make([]string, 0, len(raw))
+	for name := range raw {
+		applied = append(applied, name)
+	}
+	sort.Strings(applied)
+	config.environmentOverrides = applied
+
+	return config.UpdateFrom(raw, EnvironmentVariable)
+}
+
+// EnvironmentOverrides returns the names (never the values) of the config parameters most
+// recently applied by ScanEnvironmentOverrides, so operators can see what's been tuned per-node
+// without Felix leaking secret values (e.g. EtcdKeyFile paths) into logs or debug endpoints.
+func (config *Config) EnvironmentOverrides() []string {
+	out := make([]string, len(config.environmentOverrides))
+	copy(out, config.environmentOverrides)
+	return out
+}
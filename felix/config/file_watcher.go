@@ -0,0 +1,197 @@
+// Copyright (c) 2025 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/fsnotify/fsnotify"
+	log "github.com/sirupsen/logrus"
+)
+
+// ConfigWatcher watches the on-disk file named by --config-file for changes and hot-applies the
+// ones that are safe to take effect without a restart, instead of relying on an operator (or the
+// daemon wrapper) to bounce Felix for every benign tweak, e.g. a log severity change.
+//
+// Fields whose Metadata.ReloadPolicy is ReloadRestart still require the existing
+// restart-on-change path: reload() merges the file into Config first, but when the update touches
+// one of them it notifies no OnReload subscriber and returns ErrRestartRequired instead, the same
+// all-or-nothing behaviour as Reloader.Apply.
+type ConfigWatcher struct {
+	path    string
+	config  *Config
+	watcher *fsnotify.Watcher
+	stopCh  chan struct{}
+}
+
+// NewConfigWatcher creates a watcher for the Felix config file at path. Call Start to begin
+// watching in the background; call Stop to shut it down.
+func NewConfigWatcher(path string, config *Config) (*ConfigWatcher, error) {
+	fsWatcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create config file watcher: %w", err)
+	}
+	// Watch the containing directory rather than the file itself: config-management tools (and
+	// a ConfigMap projected volume in particular) typically replace the file via rename rather
+	// than writing it in place, which wouldn't re-arm an inotify watch held on the old inode.
+	dir := filepath.Dir(path)
+	if err := fsWatcher.Add(dir); err != nil {
+		fsWatcher.Close()
+		return nil, fmt.Errorf("failed to watch %s: %w", dir, err)
+	}
+	return &ConfigWatcher{
+		path:    path,
+		config:  config,
+		watcher: fsWatcher,
+		stopCh:  make(chan struct{}),
+	}, nil
+}
+
+// Start begins processing filesystem events in a background goroutine and returns immediately.
+func (w *ConfigWatcher) Start() {
+	go w.loop()
+}
+
+// Stop shuts the watcher down. It is not safe to call more than once.
+func (w *ConfigWatcher) Stop() {
+	close(w.stopCh)
+	w.watcher.Close()
+}
+
+func (w *ConfigWatcher) loop() {
+	for {
+		select {
+		case <-w.stopCh:
+			return
+		case event, ok := <-w.watcher.Events:
+			if !ok {
+				return
+			}
+			if filepath.Clean(event.Name) != filepath.Clean(w.path) {
+				continue
+			}
+			if event.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Rename) == 0 {
+				continue
+			}
+			if err := w.reload(); err != nil {
+				log.WithError(err).WithField("path", w.path).Warn(
+					"Failed to apply updated Felix config file; keeping previous configuration.")
+			}
+		case err, ok := <-w.watcher.Errors:
+			if !ok {
+				return
+			}
+			log.WithError(err).Warn("Error from config file watcher.")
+		}
+	}
+}
+
+// reload re-parses the config file, merges it in at the ConfigFile priority level, and either
+// fans the resulting changes out to OnReload subscribers or returns ErrRestartRequired, the same
+// all-or-nothing contract as Reloader.Apply.
+func (w *ConfigWatcher) reload() error {
+	raw, err := parseConfigFile(w.path)
+	if err != nil {
+		return err
+	}
+
+	historyBefore := len(w.config.DiffHistory())
+	changed, err := w.config.UpdateFrom(raw, ConfigFile)
+	if err != nil {
+		return err
+	}
+	if !changed {
+		return nil
+	}
+	history := w.config.DiffHistory()
+	if len(history) == historyBefore {
+		// Nothing actually changed once validators ran (e.g. a bad value fell back to its
+		// default, which happened to match); nothing to reload.
+		return nil
+	}
+	diff := history[len(history)-1]
+
+	var restartFields []string
+	liveChanges := map[string]string{}
+	for _, c := range diff.Changes {
+		param, ok := knownParams[strings.ToLower(c.Field)]
+		if !ok {
+			continue
+		}
+		if param.GetMetadata().ReloadPolicy != ReloadLive {
+			restartFields = append(restartFields, c.Field)
+			continue
+		}
+		liveChanges[c.Field] = raw[strings.ToLower(c.Field)]
+	}
+
+	if len(restartFields) > 0 {
+		log.WithField("fields", restartFields).Warn(
+			"Config file change includes fields that require a Felix restart to take effect.")
+		return &ErrRestartRequired{Fields: restartFields}
+	}
+
+	w.config.notifyReload(liveChanges)
+	return nil
+}
+
+// parseConfigFile reads a Felix config file (simple "key = value" lines, blank lines, "#"/";"
+// comments, and an optional ignored "[section]" header) into a raw name->value map keyed by
+// lower-cased field name, matching the format Param.Parse expects from UpdateFrom.
+func parseConfigFile(path string) (map[string]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	raw := map[string]string{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") || strings.HasPrefix(line, "[") {
+			continue
+		}
+		name, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		raw[strings.ToLower(strings.TrimSpace(name))] = strings.TrimSpace(value)
+	}
+	return raw, scanner.Err()
+}
+
+// OnReload registers cb to be called with the raw values of the live-reloadable fields that
+// changed, whenever a ConfigWatcher applies a config file update in place.  Subsystems that only
+// care about a handful of keys (the BPF dataplane watching XDPEnabled, the iptables generator
+// watching the *MarkMask fields, the BGP layer watching the BGP* fields) should filter the
+// supplied map themselves rather than each running their own watcher.
+//
+// A separate process (e.g. a split-out dataplane driver) that needs a full Config mirror should
+// instead take config.ToConfigUpdate() after a reload and reconstruct it there with
+// FromConfigUpdate, the same plumbing already used to ship config over the wire today.
+func (config *Config) OnReload(cb func(changed map[string]string)) {
+	config.reloadCallbacks = append(config.reloadCallbacks, cb)
+}
+
+func (config *Config) notifyReload(changed map[string]string) {
+	for _, cb := range config.reloadCallbacks {
+		cb(changed)
+	}
+}
@@ -0,0 +1,201 @@
+// Copyright (c) 2025 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"math"
+	"os"
+	"runtime"
+	"runtime/debug"
+	"strconv"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	cgroupV2MemoryMax  = "/sys/fs/cgroup/memory.max"
+	cgroupV2MemoryHigh = "/sys/fs/cgroup/memory.high"
+	cgroupV2CPUMax     = "/sys/fs/cgroup/cpu.max"
+	cgroupV2PSIMemory  = "/sys/fs/cgroup/memory.pressure"
+
+	cgroupV1MemoryLimit = "/sys/fs/cgroup/memory/memory.limit_in_bytes"
+	cgroupV1CPUQuota    = "/sys/fs/cgroup/cpu/cpu.cfs_quota_us"
+	cgroupV1CPUPeriod   = "/sys/fs/cgroup/cpu/cpu.cfs_period_us"
+
+	// memoryLimitFraction is the fraction of the cgroup's memory limit we pass to
+	// debug.SetMemoryLimit; leaving headroom avoids the runtime driving the process into OOM-kill
+	// territory while still reacting to GC pressure well before the hard limit.
+	memoryLimitFraction = 0.9
+
+	// pressureGOGC is the GOGC value we temporarily fall back to when cgroup v2 reports memory
+	// pressure, to bring memory usage down faster than the tuned steady-state value would.
+	pressureGOGC = 50
+)
+
+// GoRuntimeAutoTuner periodically derives GOGC, GOMEMLIMIT and GOMAXPROCS from the container's
+// cgroup memory/cpu limits, so Felix behaves well in whatever size container the operator
+// happens to have sized it, without requiring a restart to pick up limit changes.
+type GoRuntimeAutoTuner struct {
+	config   *Config
+	interval time.Duration
+	stopCh   chan struct{}
+
+	steadyStateGOGC int
+}
+
+// NewGoRuntimeAutoTuner builds an autotuner for config.  Call Start to begin the periodic tuning
+// loop; the first tune happens synchronously so the runtime is tuned before Start returns.
+func NewGoRuntimeAutoTuner(config *Config, interval time.Duration) *GoRuntimeAutoTuner {
+	return &GoRuntimeAutoTuner{
+		config:          config,
+		interval:        interval,
+		stopCh:          make(chan struct{}),
+		steadyStateGOGC: 100,
+	}
+}
+
+// Start applies the initial tuning and launches the periodic re-tune / pressure-watch loop in a
+// background goroutine.
+func (t *GoRuntimeAutoTuner) Start() {
+	t.tune()
+	go t.loop()
+}
+
+// Stop terminates the background tuning loop.
+func (t *GoRuntimeAutoTuner) Stop() {
+	close(t.stopCh)
+}
+
+func (t *GoRuntimeAutoTuner) loop() {
+	ticker := time.NewTicker(t.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			t.tune()
+		case <-t.stopCh:
+			return
+		}
+	}
+}
+
+func (t *GoRuntimeAutoTuner) tune() {
+	if !t.config.GoRuntimeAutoTune {
+		return
+	}
+
+	if limit, ok := readCgroupMemoryLimit(); ok {
+		memLimit := int64(float64(limit) * memoryLimitFraction)
+		debug.SetMemoryLimit(memLimit)
+		log.WithField("limitBytes", memLimit).Info("Auto-tuned GOMEMLIMIT from cgroup memory limit.")
+	}
+
+	if quota, ok := readCgroupCPUQuota(); ok {
+		procs := int(math.Ceil(quota))
+		if procs < 1 {
+			procs = 1
+		}
+		runtime.GOMAXPROCS(procs)
+		log.WithField("GOMAXPROCS", procs).Info("Auto-tuned GOMAXPROCS from cgroup CPU quota.")
+	}
+
+	gogc := t.steadyStateGOGC
+	if underMemoryPressure() {
+		gogc = pressureGOGC
+		log.Warn("Detected cgroup memory pressure, temporarily lowering GOGC.")
+	}
+	debug.SetGCPercent(gogc)
+}
+
+// readCgroupMemoryLimit returns the effective memory limit in bytes, preferring cgroup v2's
+// memory.max (falling back to memory.high if memory.max is "max"/unset) and then cgroup v1's
+// memory.limit_in_bytes.  ok is false if no limit could be determined (e.g. not containerized).
+func readCgroupMemoryLimit() (int64, bool) {
+	if v, ok := readCgroupInt64(cgroupV2MemoryMax); ok {
+		return v, true
+	}
+	if v, ok := readCgroupInt64(cgroupV2MemoryHigh); ok {
+		return v, true
+	}
+	if v, ok := readCgroupInt64(cgroupV1MemoryLimit); ok {
+		return v, true
+	}
+	return 0, false
+}
+
+// readCgroupCPUQuota returns the number of CPUs available to the container, derived from
+// cgroup v2's cpu.max ("$quota $period") or cgroup v1's cpu.cfs_quota_us/cpu.cfs_period_us pair.
+func readCgroupCPUQuota() (float64, bool) {
+	if raw, err := os.ReadFile(cgroupV2CPUMax); err == nil {
+		fields := strings.Fields(strings.TrimSpace(string(raw)))
+		if len(fields) == 2 && fields[0] != "max" {
+			quota, err1 := strconv.ParseFloat(fields[0], 64)
+			period, err2 := strconv.ParseFloat(fields[1], 64)
+			if err1 == nil && err2 == nil && period > 0 {
+				return quota / period, true
+			}
+		}
+		return 0, false
+	}
+
+	quota, okQ := readCgroupInt64(cgroupV1CPUQuota)
+	period, okP := readCgroupInt64(cgroupV1CPUPeriod)
+	if okQ && okP && quota > 0 && period > 0 {
+		return float64(quota) / float64(period), true
+	}
+	return 0, false
+}
+
+func readCgroupInt64(path string) (int64, bool) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return 0, false
+	}
+	s := strings.TrimSpace(string(raw))
+	if s == "max" || s == "-1" || s == "" {
+		return 0, false
+	}
+	v, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return v, true
+}
+
+// underMemoryPressure reports whether the cgroup v2 PSI memory.pressure file shows any non-zero
+// "some avg10" value, indicating the container is starting to stall on memory reclaim.
+func underMemoryPressure() bool {
+	raw, err := os.ReadFile(cgroupV2PSIMemory)
+	if err != nil {
+		return false
+	}
+	for _, line := range strings.Split(string(raw), "\n") {
+		if !strings.HasPrefix(line, "some ") {
+			continue
+		}
+		for _, field := range strings.Fields(line) {
+			if !strings.HasPrefix(field, "avg10=") {
+				continue
+			}
+			v, err := strconv.ParseFloat(strings.TrimPrefix(field, "avg10="), 64)
+			if err == nil && v > 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
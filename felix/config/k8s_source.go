@@ -0,0 +1,112 @@
+// Copyright (c) 2025 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// K8sConfigMapRef names a single ConfigMap or Secret that contributes config values at the
+// KubernetesConfigMap priority level.  Secrets are used for TLS material (e.g. EtcdKeyFile,
+// TyphaCertFile) that operators don't want to put in a plaintext ConfigMap.
+type K8sConfigMapRef struct {
+	Namespace string
+	Name      string
+	IsSecret  bool
+}
+
+// K8sObjectGetter fetches the current key/value data of a ConfigMap or Secret.  It is satisfied
+// by a thin adapter over the client-go informer caches so this package doesn't need to import
+// k8s.io/client-go directly.
+type K8sObjectGetter interface {
+	// Get returns the data of the given ConfigMap/Secret, or nil if it doesn't exist yet.
+	Get(ref K8sConfigMapRef) (map[string]string, error)
+	// AddEventHandler registers onChange to be called (with no arguments) whenever any watched
+	// object changes; the caller debounces before reacting.
+	AddEventHandler(onChange func())
+}
+
+// KubernetesConfigMapWatcher merges one or more ConfigMaps/Secrets into a Config at the
+// KubernetesConfigMap priority level, debouncing rapid-fire updates and only re-resolving once
+// per debounce window.
+type KubernetesConfigMapWatcher struct {
+	config       *Config
+	refs         []K8sConfigMapRef
+	getter       K8sObjectGetter
+	debounce     time.Duration
+	pendingTimer *time.Timer
+	OnChanged    func(changedFields []string)
+}
+
+// NewKubernetesConfigMapWatcher builds a watcher for the given refs without reading them.  Call
+// Start to begin watching; Start does the first merge synchronously, so Config reflects the
+// ConfigMaps/Secrets as soon as Start returns.
+func NewKubernetesConfigMapWatcher(config *Config, refs []K8sConfigMapRef, getter K8sObjectGetter, debounce time.Duration) *KubernetesConfigMapWatcher {
+	w := &KubernetesConfigMapWatcher{
+		config:   config,
+		refs:     refs,
+		getter:   getter,
+		debounce: debounce,
+	}
+	return w
+}
+
+// Start performs an initial merge and registers for future change notifications.
+func (w *KubernetesConfigMapWatcher) Start() error {
+	if err := w.mergeAndResolve(); err != nil {
+		return err
+	}
+	w.getter.AddEventHandler(w.onChange)
+	return nil
+}
+
+func (w *KubernetesConfigMapWatcher) onChange() {
+	if w.pendingTimer != nil {
+		w.pendingTimer.Stop()
+	}
+	w.pendingTimer = time.AfterFunc(w.debounce, func() {
+		if err := w.mergeAndResolve(); err != nil {
+			log.WithError(err).Warn("Failed to apply Kubernetes ConfigMap/Secret config update.")
+		}
+	})
+}
+
+func (w *KubernetesConfigMapWatcher) mergeAndResolve() error {
+	merged := map[string]string{}
+	for _, ref := range w.refs {
+		data, err := w.getter.Get(ref)
+		if err != nil {
+			return err
+		}
+		for k, v := range data {
+			merged[k] = v
+		}
+	}
+
+	changed, err := w.config.UpdateFrom(merged, KubernetesConfigMap)
+	if err != nil {
+		return err
+	}
+	if changed && w.OnChanged != nil {
+		changedFields := make([]string, 0)
+		for k := range merged {
+			changedFields = append(changedFields, k)
+		}
+		w.OnChanged(changedFields)
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+// Copyright (c) 2025 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// NetworkManagerConfMode indicates whether Felix should manage the NetworkManager "unmanaged
+// devices" drop-in file, matching the NetworkManagerConfigMode config param.
+type NetworkManagerConfMode string
+
+const (
+	NMConfModeAuto     NetworkManagerConfMode = "Auto"
+	NMConfModeEnabled  NetworkManagerConfMode = "Enabled"
+	NMConfModeDisabled NetworkManagerConfMode = "Disabled"
+)
+
+// NetworkManagerReconciler keeps the NetworkManager "unmanaged devices" conf.d file in sync with
+// the interface patterns that Felix owns, so NetworkManager doesn't race Felix to configure
+// freshly-created Calico veths, the Wireguard interface, or VXLAN/IPIP tunnels.
+type NetworkManagerReconciler struct {
+	confFile    string
+	lastWritten string
+}
+
+// NewNetworkManagerReconciler creates a reconciler that writes to the given conf.d path.
+func NewNetworkManagerReconciler(confFile string) *NetworkManagerReconciler {
+	return &NetworkManagerReconciler{confFile: confFile}
+}
+
+// UnmanagedDevicePatterns derives the set of interface-match patterns that NetworkManager should
+// leave alone, from the fields of Config that already describe Calico-owned interfaces.
+func (config *Config) UnmanagedDevicePatterns() []string {
+	patterns := []string{
+		"interface-name:" + config.InterfacePrefix + "*",
+	}
+	if config.WireguardEnabled {
+		patterns = append(patterns, "interface-name:"+config.WireguardInterfaceName)
+	}
+	if config.WireguardEnabledV6 {
+		patterns = append(patterns, "interface-name:"+config.WireguardInterfaceNameV6)
+	}
+	if config.Encapsulation.VXLANEnabled || config.Encapsulation.VXLANEnabledV6 {
+		patterns = append(patterns, "interface-name:vxlan.calico", "interface-name:vxlan-v6.calico")
+	}
+	if config.Encapsulation.IPIPEnabled {
+		patterns = append(patterns, "interface-name:tunl0")
+	}
+	if config.BPFEnabled && config.BPFDataIfacePattern != nil {
+		patterns = append(patterns, "interface-name:"+config.BPFDataIfacePattern.String())
+	}
+	for _, re := range config.NetworkManagerExtraInterfacePatterns {
+		patterns = append(patterns, "interface-name:"+re.String())
+	}
+	return patterns
+}
+
+// shouldManageNetworkManager decides, based on NetworkManagerConfigMode, whether the reconciler should write the
+// conf.d file at all.  In Auto mode we only do so if nmcli (and therefore NetworkManager) appears
+// to be present on the host.
+func (config *Config) shouldManageNetworkManager() bool {
+	switch config.NetworkManagerConfigMode {
+	case string(NMConfModeEnabled):
+		return true
+	case string(NMConfModeDisabled):
+		return false
+	default: // Auto
+		_, err := exec.LookPath("nmcli")
+		return err == nil
+	}
+}
+
+// Apply writes (or removes) the NetworkManager conf.d drop-in file to match the current Config,
+// and asks NetworkManager to reload if the content changed.
+func (r *NetworkManagerReconciler) Apply(config *Config) error {
+	if !config.shouldManageNetworkManager() {
+		return r.Remove()
+	}
+
+	var sb strings.Builder
+	sb.WriteString("# Generated by Felix; do not edit.\n[keyfile]\nunmanaged-devices=")
+	sb.WriteString(strings.Join(config.UnmanagedDevicePatterns(), ";"))
+	sb.WriteString("\n")
+	content := sb.String()
+
+	if content == r.lastWritten {
+		return nil
+	}
+
+	tmpFile := r.confFile + ".tmp"
+	if err := os.WriteFile(tmpFile, []byte(content), 0o644); err != nil {
+		return fmt.Errorf("failed to write NetworkManager conf.d file: %w", err)
+	}
+	if err := os.Rename(tmpFile, r.confFile); err != nil {
+		return fmt.Errorf("failed to install NetworkManager conf.d file: %w", err)
+	}
+	r.lastWritten = content
+
+	if err := exec.Command("nmcli", "general", "reload").Run(); err != nil {
+		log.WithError(err).Warn("Failed to ask NetworkManager to reload; it may not notice the unmanaged-devices change until it is restarted.")
+	}
+	return nil
+}
+
+// Remove deletes the conf.d file (e.g. on shutdown or when the mode flips to Disabled) and
+// restores NetworkManager's default behaviour.
+func (r *NetworkManagerReconciler) Remove() error {
+	if r.lastWritten == "" {
+		return nil
+	}
+	if err := os.Remove(r.confFile); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove NetworkManager conf.d file: %w", err)
+	}
+	r.lastWritten = ""
+	if err := exec.Command("nmcli", "general", "reload").Run(); err != nil {
+		log.WithError(err).Warn("Failed to ask NetworkManager to reload after removing unmanaged-devices file.")
+	}
+	return nil
+}
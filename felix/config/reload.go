@@ -0,0 +1,120 @@
+// Copyright (c) 2025 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/projectcalico/calico/libcalico-go/lib/set"
+)
+
+// ReloadPolicy classifies whether a config field can be applied to a running Felix process
+// without a restart.  Fields default to ReloadRestart unless tagged `reload:"live"`.
+type ReloadPolicy string
+
+const (
+	ReloadRestart ReloadPolicy = "restart"
+	ReloadLive    ReloadPolicy = "live"
+)
+
+// ChangeEvent describes a single field that changed value as part of a live reload.
+type ChangeEvent struct {
+	Field    string
+	OldValue interface{}
+	NewValue interface{}
+}
+
+// ErrRestartRequired is returned by Reloader.Apply when one or more of the changed fields are
+// not safe to apply live; the caller should fall back to the existing die-and-restart behaviour.
+type ErrRestartRequired struct {
+	Fields []string
+}
+
+func (e *ErrRestartRequired) Error() string {
+	return fmt.Sprintf("config fields require a restart to take effect: %v", e.Fields)
+}
+
+// Reloader re-parses the configured sources, diffs the result against the previous Config, and
+// either dispatches live ChangeEvents to subscribers or signals that a restart is required.
+type Reloader struct {
+	current     *Config
+	subscribers []chan<- ChangeEvent
+}
+
+// NewReloader wraps an already-resolved Config for subsequent hot-reload passes.
+func NewReloader(initial *Config) *Reloader {
+	return &Reloader{current: initial}
+}
+
+// Subscribe registers a channel that receives one ChangeEvent per live-reloadable field that
+// changes value on a future Apply call.  Subscribers are expected to keep up; Apply does not
+// block indefinitely on a full channel.
+func (r *Reloader) Subscribe(ch chan<- ChangeEvent) {
+	r.subscribers = append(r.subscribers, ch)
+}
+
+// Apply re-resolves the Config (the caller having already merged new raw data from the
+// datastore or SIGHUP-triggered re-read into r.current's sources), and either dispatches live
+// change events or returns ErrRestartRequired naming the offending fields.
+func (r *Reloader) Apply() (changedFields set.Set[string], err error) {
+	before := r.current.Copy()
+	changedFields, err = r.current.resolve()
+	if err != nil {
+		return changedFields, err
+	}
+	if changedFields.Len() == 0 {
+		return changedFields, nil
+	}
+
+	var restartFields []string
+	var liveEvents []ChangeEvent
+	changedFields.Iter(func(name string) error {
+		param, ok := knownParams[strings.ToLower(name)]
+		if !ok {
+			return nil
+		}
+		metadata := param.GetMetadata()
+		oldV := reflect.ValueOf(before).Elem().FieldByName(name).Interface()
+		newV := reflect.ValueOf(r.current).Elem().FieldByName(name).Interface()
+		if metadata.ReloadPolicy != ReloadLive {
+			restartFields = append(restartFields, name)
+			return nil
+		}
+		liveEvents = append(liveEvents, ChangeEvent{Field: name, OldValue: oldV, NewValue: newV})
+		return nil
+	})
+
+	if len(restartFields) > 0 {
+		log.WithField("fields", restartFields).Warn(
+			"Configuration change requires a Felix restart to take effect.")
+		return changedFields, &ErrRestartRequired{Fields: restartFields}
+	}
+
+	for _, ev := range liveEvents {
+		for _, sub := range r.subscribers {
+			select {
+			case sub <- ev:
+			default:
+				log.WithField("field", ev.Field).Warn(
+					"Reload subscriber channel is full; dropping live config change event.")
+			}
+		}
+	}
+	return changedFields, nil
+}
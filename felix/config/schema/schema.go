@@ -0,0 +1,75 @@
+// Copyright (c) 2025 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package schema renders machine-readable descriptions of the Felix config surface, so that the
+// CRD, OpenAPI schema, and `calicoctl explain felixconfig` output can be generated from, and
+// checked against, the same source of truth that felix/config.loadParams() uses at runtime. The
+// descriptions themselves come from config.BuildSchemaDocument, the one reflection pass this
+// package and felix/config's own SchemaJSON/DumpSchema share; this package only reshapes that
+// document into the narrower views its callers want.
+package schema
+
+import (
+	"github.com/projectcalico/calico/felix/config"
+)
+
+// FieldSchema is a machine-readable description of a single Config field.
+type FieldSchema struct {
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	Description string `json:"description"`
+	Default     string `json:"default,omitempty"`
+	NonZero     bool   `json:"nonZero,omitempty"`
+	DieOnFail   bool   `json:"dieOnFail,omitempty"`
+	Local       bool   `json:"local,omitempty"`
+}
+
+// Document is the top-level machine-readable dump of the Felix config surface.
+type Document struct {
+	Fields []FieldSchema `json:"fields"`
+}
+
+// Build renders config.BuildSchemaDocument's output as a Document, for callers that only want
+// this package's narrower field set (no enum/deprecation metadata or source priority).
+func Build() Document {
+	fields := config.BuildSchemaDocument().Fields
+	doc := Document{Fields: make([]FieldSchema, 0, len(fields))}
+	for _, f := range fields {
+		doc.Fields = append(doc.Fields, FieldSchema{
+			Name:        f.Name,
+			Type:        f.Type,
+			Description: f.Description,
+			Default:     f.Default,
+			NonZero:     f.NonZero,
+			DieOnFail:   f.DieOnFail,
+			Local:       f.Local,
+		})
+	}
+	return doc
+}
+
+// CRDValidationFragment renders the subset of the schema that the FelixConfiguration CRD's
+// OpenAPI v3 `spec.properties` fragment needs: just name, type and description, since Kubernetes
+// CRD validation doesn't have a direct equivalent for all of our `config:"..."` flags.
+func CRDValidationFragment() map[string]map[string]string {
+	doc := Build()
+	out := make(map[string]map[string]string, len(doc.Fields))
+	for _, f := range doc.Fields {
+		out[f.Name] = map[string]string{
+			"type":        "string",
+			"description": f.Description,
+		}
+	}
+	return out
+}
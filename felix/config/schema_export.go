@@ -0,0 +1,151 @@
+// Copyright (c) 2025 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"encoding/json"
+	"io"
+	"sort"
+)
+
+// SchemaDocVersion is bumped whenever the shape of SchemaDocument (or the semantics of an
+// existing field within it) changes in a way that could break a downstream consumer that diffs
+// this output in CI.  Purely additive new fields on ParamJSONSchema do not need a bump.
+const SchemaDocVersion = 1
+
+// ParamJSONSchema is the JSON Schema-ish description of a single known config param, as produced
+// by SchemaJSON().  It deliberately only uses JSON-primitive fields so external tools (the
+// operator, IDE plugins, `calicoctl explain felixconfig`) can consume it without importing this
+// package.
+type ParamJSONSchema struct {
+	Name            string   `json:"name"`
+	Type            string   `json:"type"`
+	Enum            []string `json:"enum,omitempty"`
+	Default         string   `json:"default"`
+	NonZero         bool     `json:"nonZero,omitempty"`
+	DieOnFail       bool     `json:"dieOnFail,omitempty"`
+	Local           bool     `json:"local,omitempty"`
+	Description     string   `json:"description"`
+	Deprecated      bool     `json:"deprecated,omitempty"`
+	DeprecatedSince string   `json:"deprecatedSince,omitempty"`
+	ReplacedBy      string   `json:"replacedBy,omitempty"`
+	RemovedIn       string   `json:"removedIn,omitempty"`
+}
+
+// SchemaDocument is the top-level document rendered by DumpSchema.  SourcePriority is included
+// so that downstream tooling doesn't need to hard-code Felix's config source precedence
+// alongside its own copy of the field list.
+type SchemaDocument struct {
+	Version        int               `json:"version"`
+	SourcePriority []string          `json:"sourcePriority"`
+	Fields         []ParamJSONSchema `json:"fields"`
+}
+
+// BuildSchemaDocument walks knownParams (populated by loadParams) and assembles a SchemaDocument
+// describing every known Config field, stably sorted by name so it can be diffed in CI. It's
+// exported so that other schema-export views (e.g. felix/config/schema's CRD-fragment rendering)
+// can build on this one reflection pass over Params() instead of duplicating it.
+func BuildSchemaDocument() SchemaDocument {
+	params := Params()
+	names := make([]string, 0, len(params))
+	for name := range params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fields := make([]ParamJSONSchema, 0, len(names))
+	for _, name := range names {
+		param := params[name]
+		metadata := param.GetMetadata()
+		schema := ParamJSONSchema{
+			Name:        metadata.Name,
+			Type:        metadata.Type,
+			Default:     metadata.DefaultString,
+			NonZero:     metadata.NonZero,
+			DieOnFail:   metadata.DieOnParseFailure,
+			Local:       metadata.Local,
+			Description: param.SchemaDescription(),
+		}
+		if metadata.DeprecatedSince != "" {
+			schema.Deprecated = true
+			schema.DeprecatedSince = metadata.DeprecatedSince
+			schema.ReplacedBy = metadata.ReplacedBy
+			schema.RemovedIn = metadata.RemovedIn
+		}
+		if oneOf, ok := param.(*OneofListParam); ok {
+			for _, canon := range oneOf.lowerCaseOptionsToCanonical {
+				schema.Enum = append(schema.Enum, canon)
+			}
+			sort.Strings(schema.Enum)
+		}
+		fields = append(fields, schema)
+	}
+
+	sourcePriority := make([]string, 0, len(SourcesInDescendingOrder))
+	for _, source := range SourcesInDescendingOrder {
+		sourcePriority = append(sourcePriority, source.String())
+	}
+
+	return SchemaDocument{
+		Version:        SchemaDocVersion,
+		SourcePriority: sourcePriority,
+		Fields:         fields,
+	}
+}
+
+// SchemaJSON walks knownParams (populated by loadParams) and renders a JSON Schema-style
+// document describing every known Config field, stably sorted by name so it can be diffed in CI.
+func (config *Config) SchemaJSON() ([]byte, error) {
+	return json.MarshalIndent(BuildSchemaDocument(), "", "  ")
+}
+
+// DumpSchema renders the same document as SchemaJSON directly to w, without requiring the
+// caller to hold the whole serialized schema in memory.  It's the entry point used by the
+// felix-config-schema CLI tool so that downstream tooling (the operator, kubectl-calico, docs
+// generators, IDE plugins) can validate FelixConfiguration CRs against Felix's actual config
+// surface without importing the felix/config package.
+func DumpSchema(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(BuildSchemaDocument())
+}
+
+// OpenAPIFragment renders a minimal OpenAPI v3 `properties` fragment for the FelixConfiguration
+// spec, suitable for embedding in the operator/CRD tooling's generated schema.
+func (config *Config) OpenAPIFragment() (map[string]interface{}, error) {
+	raw, err := config.SchemaJSON()
+	if err != nil {
+		return nil, err
+	}
+	var doc struct {
+		Fields []ParamJSONSchema `json:"fields"`
+	}
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return nil, err
+	}
+
+	properties := make(map[string]interface{}, len(doc.Fields))
+	for _, f := range doc.Fields {
+		prop := map[string]interface{}{
+			"type":        "string",
+			"description": f.Description,
+		}
+		if len(f.Enum) > 0 {
+			prop["enum"] = f.Enum
+		}
+		properties[f.Name] = prop
+	}
+	return map[string]interface{}{"properties": properties}, nil
+}
@@ -0,0 +1,94 @@
+// Copyright (c) 2025 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"sort"
+	"strings"
+)
+
+// SourceValue is one source's contribution towards a single parameter, in descending priority
+// order (the first entry in a SourceBreakdown.Sources slice is the one currently in effect).
+type SourceValue struct {
+	Source string `json:"source"`
+	Value  string `json:"value"`
+}
+
+// SourceBreakdown describes every source that currently supplies a value for a single known
+// parameter, and which one wins.  See Config.SourceBreakdown and the /config debug endpoint.
+type SourceBreakdown struct {
+	Name    string        `json:"name"`
+	Winner  string        `json:"winner"`
+	Sources []SourceValue `json:"sources"`
+}
+
+// SourceBreakdown walks Config.sourceToRawConfig (which also captures the most recent
+// internalOverrides, since OverrideParam round-trips them through UpdateFrom) and returns, for
+// every known parameter that has at least one contributing source, the raw value each source
+// supplied, highest priority first, and which source's value is currently in effect.  Values for
+// parameters flagged Metadata.Sensitive (Etcd/Typha private key paths, and the like) are
+// redacted to "<set>" so the breakdown can safely be exposed over HTTP.
+func (config *Config) SourceBreakdown() []SourceBreakdown {
+	params := Params()
+	names := make([]string, 0, len(params))
+	for name := range params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	out := make([]SourceBreakdown, 0, len(names))
+	for _, name := range names {
+		param := params[name]
+		metadata := param.GetMetadata()
+		lowerName := strings.ToLower(name)
+
+		var sources []SourceValue
+		for _, source := range SourcesInDescendingOrder {
+			value, ok := lookupRawValue(config.sourceToRawConfig[source], lowerName)
+			if !ok {
+				continue
+			}
+			if metadata.Sensitive {
+				value = "<set>"
+			}
+			sources = append(sources, SourceValue{Source: source.String(), Value: value})
+		}
+		if metadata.DefaultString != "" {
+			sources = append(sources, SourceValue{Source: Default.String(), Value: metadata.DefaultString})
+		}
+		if len(sources) == 0 {
+			continue
+		}
+
+		out = append(out, SourceBreakdown{
+			Name:    metadata.Name,
+			Winner:  sources[0].Source,
+			Sources: sources,
+		})
+	}
+	return out
+}
+
+// lookupRawValue looks name up in raw case-insensitively, since different sources populate
+// sourceToRawConfig with different casing conventions for the raw key (e.g. ScanEnvironmentOverrides
+// lower-cases it, the datastore sources use the field's canonical name).
+func lookupRawValue(raw map[string]string, lowerName string) (string, bool) {
+	for k, v := range raw {
+		if strings.ToLower(k) == lowerName {
+			return v, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,99 @@
+// Copyright (c) 2025 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// Validator is a cross-field check registered against the Config type.  Features that depend on
+// more than one field being set consistently (BPF, Wireguard, nftables, flow logs, the Goldmane
+// server, ...) should register their own Validator next to the fields they own, rather than
+// adding another branch to Validate().
+type Validator func(*Config) error
+
+var registeredValidators = map[string]Validator{}
+
+// RegisterValidator adds a named cross-field validator that will be run by resolve() (and
+// Validate()) in addition to the struct-tag-driven `validate:"requires=..."` checks.  Registering
+// the same name twice is a programming error and panics, same as a duplicate config field tag
+// would.
+func RegisterValidator(name string, fn Validator) {
+	if _, ok := registeredValidators[name]; ok {
+		panic(fmt.Sprintf("validator %q registered twice", name))
+	}
+	registeredValidators[name] = fn
+}
+
+// validationFailure pairs a validator's error with the names it implicates, so callers can
+// surface precisely which invariant broke rather than a single top-level error.  For a
+// `requires=` failure these are the two field names; for a RegisterValidator callback it is the
+// name the validator was registered under.
+type validationFailure struct {
+	fields []string
+	err    error
+}
+
+// runValidators runs the struct-tag `requires=` checks followed by every RegisterValidator
+// callback, in a stable (sorted-by-name) order so failures are deterministic across runs.
+func runValidators(config *Config) []validationFailure {
+	var failures []validationFailure
+
+	failures = append(failures, checkRequiresTags(config)...)
+
+	names := make([]string, 0, len(registeredValidators))
+	for name := range registeredValidators {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err := registeredValidators[name](config); err != nil {
+			failures = append(failures, validationFailure{
+				fields: []string{name},
+				err:    fmt.Errorf("%s: %w", name, err),
+			})
+		}
+	}
+	return failures
+}
+
+// checkRequiresTags enforces the `validate:"requires=FieldA,FieldB"` struct tag: if the tagged
+// field has a non-zero value, every field it names must also be non-zero.
+func checkRequiresTags(config *Config) []validationFailure {
+	var failures []validationFailure
+	for _, param := range knownParams {
+		metadata := param.GetMetadata()
+		if len(metadata.RequiresFields) == 0 {
+			continue
+		}
+		field := reflect.ValueOf(config).Elem().FieldByName(metadata.Name)
+		if field.IsZero() {
+			continue
+		}
+		for _, required := range metadata.RequiresFields {
+			requiredField := reflect.ValueOf(config).Elem().FieldByName(required)
+			if requiredField.IsZero() {
+				failures = append(failures, validationFailure{
+					fields: []string{metadata.Name, required},
+					err: fmt.Errorf(
+						"%s is set but requires %s to also be set", metadata.Name, required),
+				})
+			}
+		}
+	}
+	return failures
+}
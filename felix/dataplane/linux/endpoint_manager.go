@@ -0,0 +1,49 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package intdataplane
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/projectcalico/calico/felix/dataplane/linux/qos"
+)
+
+// endpointManager owns the per-workload dataplane state this package's QoS reconciliation reads
+// and writes: whether BPF dataplane mode is in use (and which attach type, for
+// isQoSBandwidthSupported), and qosStore, the on-disk record recordQoSState/reconcileQoSState
+// persist and reconcile against across Felix restarts.
+type endpointManager struct {
+	bpfEnabled    bool
+	bpfAttachType string
+
+	qosStore *qos.Store
+}
+
+// newEndpointManager builds an endpointManager, rooting qosStore at a "qos" subdirectory of
+// stateDir, and immediately
+// reconciling it against liveWorkloads, so any QoS state left behind by a crash (or drifted while
+// Felix was down) is repaired or garbage-collected before the first normal per-endpoint update.
+func newEndpointManager(bpfEnabled bool, bpfAttachType string, stateDir string, liveWorkloads map[string]string) (*endpointManager, error) {
+	m := &endpointManager{
+		bpfEnabled:    bpfEnabled,
+		bpfAttachType: bpfAttachType,
+		qosStore:      qos.NewStore(filepath.Join(stateDir, "qos")),
+	}
+	if err := m.reconcileQoSState(liveWorkloads); err != nil {
+		return nil, fmt.Errorf("reconciling persisted QoS state at startup: %w", err)
+	}
+	return m, nil
+}
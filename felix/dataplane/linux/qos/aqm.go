@@ -0,0 +1,253 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package qos
+
+import (
+	"fmt"
+
+	"github.com/vishvananda/netlink"
+)
+
+// AQMKind identifies which active queue management discipline a leaf qdisc installs underneath
+// the TBF rate limiter, to combat the standing queues a plain TBF builds up under sustained load.
+type AQMKind string
+
+const (
+	AQMKindFqCodel AQMKind = "fq_codel"
+	AQMKindCake    AQMKind = "cake"
+)
+
+// aqmLeafHandle is the handle the leaf AQM qdisc is always installed under, as a child of the TBF
+// qdisc's 1:0 handle that tbfQdisc installs it at.
+var aqmLeafHandle = netlink.MakeHandle(1, 1)
+
+// AQMState captures the parameters of an fq_codel or CAKE leaf qdisc installed underneath the TBF
+// shaper. Only the fields relevant to Kind are meaningful; the zero value of the others means
+// "let the kernel pick its default" the same way a zero Burst does for TokenBucketState.
+type AQMState struct {
+	Kind AQMKind
+
+	// fq_codel parameters. Target and Interval are in microseconds, Quantum and MemoryLimit in
+	// bytes, matching "tc qdisc ... fq_codel"'s own units.
+	Target      uint32
+	Interval    uint32
+	Quantum     uint32
+	MemoryLimit uint32
+	Flows       uint32
+
+	// CAKE parameters. Bandwidth is in bytes per second (CAKE can shape on its own, but here
+	// it's always layered under TBF so Bandwidth is set to match TBF's Rate, which is what lets
+	// CAKE's own per-flow fairness and overhead accounting apply to the same rate TBF enforces).
+	// Overhead is in bytes, added to every packet's size before CAKE's rate accounting.
+	Bandwidth uint64
+	Overhead  int
+	Diffserv  string
+}
+
+// Equals reports whether two AQMStates describe the same leaf qdisc configuration.
+func (a *AQMState) Equals(other *AQMState) bool {
+	if a == nil || other == nil {
+		return a == nil && other == nil
+	}
+	return *a == *other
+}
+
+// ReadIngressAQM returns the AQM leaf state currently installed underneath ifaceName's ingress
+// TBF qdisc, or nil if there is no TBF (and hence no leaf) or no AQM leaf under it.
+func ReadIngressAQM(ifaceName string) (*AQMState, error) {
+	link, err := netlink.LinkByName(ifaceName)
+	if err != nil {
+		if isNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("looking up interface %s: %w", ifaceName, err)
+	}
+	return readAQM(link)
+}
+
+// ReadEgressAQM returns the AQM leaf state currently installed underneath ifaceName's egress TBF
+// qdisc (on its IFB device), or nil if there is none.
+func ReadEgressAQM(ifaceName string) (*AQMState, error) {
+	ifb := GetIfbDeviceName(ifaceName)
+	link, err := netlink.LinkByName(ifb)
+	if err != nil {
+		if isNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("looking up IFB device %s: %w", ifb, err)
+	}
+	return readAQM(link)
+}
+
+func readAQM(link netlink.Link) (*AQMState, error) {
+	qdiscs, err := netlink.QdiscList(link)
+	if err != nil {
+		return nil, fmt.Errorf("listing qdiscs on %s: %w", link.Attrs().Name, err)
+	}
+	for _, q := range qdiscs {
+		if q.Attrs().Parent != aqmLeafHandle {
+			continue
+		}
+		switch leaf := q.(type) {
+		case *netlink.FqCodel:
+			return &AQMState{
+				Kind:        AQMKindFqCodel,
+				Target:      uint32(leaf.Target),
+				Interval:    uint32(leaf.Interval),
+				Quantum:     uint32(leaf.Quantum),
+				MemoryLimit: uint32(leaf.Memorylimit),
+				Flows:       uint32(leaf.Flows),
+			}, nil
+		case *netlink.Cake:
+			return &AQMState{
+				Kind:      AQMKindCake,
+				Bandwidth: leaf.Bandwidth,
+				Overhead:  leaf.Overhead,
+				Diffserv:  cakeDiffservName(leaf.Diffserv),
+			}, nil
+		}
+	}
+	return nil, nil
+}
+
+// CreateIngressAQM installs state as the leaf qdisc underneath ifaceName's ingress TBF. The TBF
+// qdisc must already exist (CreateIngressQdisc must have been called first).
+func CreateIngressAQM(state *AQMState, ifaceName string) error {
+	link, err := netlink.LinkByName(ifaceName)
+	if err != nil {
+		return fmt.Errorf("looking up interface %s: %w", ifaceName, err)
+	}
+	return netlink.QdiscReplace(aqmQdisc(link.Attrs().Index, state))
+}
+
+// UpdateIngressAQM replaces the existing leaf qdisc underneath ifaceName's ingress TBF with state.
+func UpdateIngressAQM(state *AQMState, ifaceName string) error {
+	return CreateIngressAQM(state, ifaceName)
+}
+
+// RemoveIngressAQM removes the leaf qdisc underneath ifaceName's ingress TBF, leaving the TBF
+// qdisc itself (and the kernel's own pfifo default leaf) in place.
+func RemoveIngressAQM(ifaceName string) error {
+	link, err := netlink.LinkByName(ifaceName)
+	if err != nil {
+		if isNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("looking up interface %s: %w", ifaceName, err)
+	}
+	return removeAQM(link)
+}
+
+// CreateEgressAQM installs state as the leaf qdisc underneath ifaceName's egress TBF, on its IFB
+// device. AddEgressQdisc must already have been called to create that TBF qdisc.
+func CreateEgressAQM(state *AQMState, ifaceName string) error {
+	ifb := GetIfbDeviceName(ifaceName)
+	link, err := netlink.LinkByName(ifb)
+	if err != nil {
+		return fmt.Errorf("looking up IFB device %s: %w", ifb, err)
+	}
+	return netlink.QdiscReplace(aqmQdisc(link.Attrs().Index, state))
+}
+
+// UpdateEgressAQM replaces the existing leaf qdisc underneath ifaceName's egress TBF with state.
+func UpdateEgressAQM(state *AQMState, ifaceName string) error {
+	return CreateEgressAQM(state, ifaceName)
+}
+
+// RemoveEgressAQM removes the leaf qdisc underneath ifaceName's egress TBF. Unlike
+// RemoveEgressQdisc, it leaves the IFB device and TBF qdisc alone -- a caller that wants both gone
+// should call RemoveEgressQdisc instead, which deletes the whole IFB device.
+func RemoveEgressAQM(ifaceName string) error {
+	ifb := GetIfbDeviceName(ifaceName)
+	link, err := netlink.LinkByName(ifb)
+	if err != nil {
+		if isNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("looking up IFB device %s: %w", ifb, err)
+	}
+	return removeAQM(link)
+}
+
+func removeAQM(link netlink.Link) error {
+	qdiscs, err := netlink.QdiscList(link)
+	if err != nil {
+		return fmt.Errorf("listing qdiscs on %s: %w", link.Attrs().Name, err)
+	}
+	for _, q := range qdiscs {
+		if q.Attrs().Parent != aqmLeafHandle {
+			continue
+		}
+		if err := netlink.QdiscDel(q); err != nil {
+			return fmt.Errorf("deleting leaf qdisc on %s: %w", link.Attrs().Name, err)
+		}
+	}
+	return nil
+}
+
+func aqmQdisc(linkIndex int, state *AQMState) netlink.Qdisc {
+	attrs := netlink.QdiscAttrs{
+		LinkIndex: linkIndex,
+		Handle:    netlink.MakeHandle(2, 0),
+		Parent:    aqmLeafHandle,
+	}
+	switch state.Kind {
+	case AQMKindCake:
+		return &netlink.Cake{
+			QdiscAttrs: attrs,
+			Bandwidth:  state.Bandwidth,
+			Overhead:   state.Overhead,
+			Diffserv:   cakeDiffservValue(state.Diffserv),
+		}
+	default:
+		return &netlink.FqCodel{
+			QdiscAttrs:  attrs,
+			Target:      uint32(state.Target),
+			Interval:    uint32(state.Interval),
+			Quantum:     uint32(state.Quantum),
+			Memorylimit: uint32(state.MemoryLimit),
+			Flows:       uint32(state.Flows),
+		}
+	}
+}
+
+// cakeDiffservValue and cakeDiffservName convert between CAKE's "diffserv" mode name (as an
+// operator would write it in a WorkloadEndpoint's QosControls) and the netlink attribute value
+// the kernel's CAKE qdisc expects.
+func cakeDiffservValue(mode string) int {
+	switch mode {
+	case "diffserv3":
+		return 0
+	case "diffserv4":
+		return 1
+	case "diffserv8":
+		return 2
+	default:
+		return 3 // besteffort
+	}
+}
+
+func cakeDiffservName(value int) string {
+	switch value {
+	case 0:
+		return "diffserv3"
+	case 1:
+		return "diffserv4"
+	case 2:
+		return "diffserv8"
+	default:
+		return "besteffort"
+	}
+}
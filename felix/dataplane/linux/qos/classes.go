@@ -0,0 +1,384 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package qos
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/vishvananda/netlink"
+)
+
+// classRootHandle is the major handle an HTB root qdisc (and hence all of its child classes) is
+// always installed at, in the same position a flat TBF qdisc would otherwise occupy: the root of
+// the host-side veth for workload ingress, or the root of the IFB device for workload egress. A
+// workload is configured with either a single flat rate (TBF, see qos.go) or a set of named
+// classes (HTB, this file), never both, so there's no conflict in reusing the handle.
+const classRootHandle = 1
+
+// ClassSpec is one named HTB class: a share of the interface's overall capacity, guaranteed at
+// Guaranteed bytes/s and allowed to borrow spare capacity from sibling classes up to Ceiling
+// bytes/s, with Priority breaking ties over which under-capacity class borrows first (lower value
+// wins, the same sense "tc class add ... htb prio" uses).
+type ClassSpec struct {
+	Name       string
+	Guaranteed uint64
+	Ceiling    uint64
+	Priority   uint32
+}
+
+// MatchRule steers packets into ClassName. A rule matches on DSCP and/or a (Protocol, Port) pair
+// and/or an fwmark (the last populated by iptables/nftables from a pod label, upstream of tc);
+// HasDSCP/HasMark distinguish "match DSCP 0" from "don't match on DSCP at all" the same way a
+// zero Protocol/Port already unambiguously means "don't match on L4" (0 is not a valid IP
+// protocol number or port to filter workload traffic on).
+type MatchRule struct {
+	ClassName string
+	HasDSCP   bool
+	DSCP      uint8
+	Protocol  uint8
+	Port      uint16
+	HasMark   bool
+	Mark      uint32
+}
+
+// ClassState is the full HTB configuration installed on an interface: its named classes and the
+// filters steering traffic into them. Two ClassStates read back-to-back, or a freshly-read one
+// compared against a freshly-built desired one, are Equal only if both the classes and the rules
+// match in the same order -- CreateIngressClasses/CreateEgressClasses always (re)build both lists
+// in the caller's order, so a stable ordering upstream (e.g. sorted by class name) is what makes
+// this comparison meaningful across reconciliation passes rather than just within one.
+type ClassState struct {
+	Classes []ClassSpec
+	Rules   []MatchRule
+}
+
+// Equals reports whether two ClassStates describe the same HTB configuration.
+func (c *ClassState) Equals(other *ClassState) bool {
+	if c == nil || other == nil {
+		return c == nil && other == nil
+	}
+	return reflect.DeepEqual(c, other)
+}
+
+// ReadIngressClasses returns the HTB configuration currently installed on ifaceName's root
+// (shaping the workload's ingress), or nil if the root qdisc isn't HTB (e.g. it's a flat TBF, or
+// nothing at all).
+func ReadIngressClasses(ifaceName string) (*ClassState, error) {
+	link, err := netlink.LinkByName(ifaceName)
+	if err != nil {
+		if isNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("looking up interface %s: %w", ifaceName, err)
+	}
+	return readClasses(link)
+}
+
+// ReadEgressClasses returns the HTB configuration currently installed on the IFB device that
+// ifaceName's egress traffic is redirected through (shaping the workload's egress), or nil if
+// there's no such IFB device, or its root isn't HTB.
+func ReadEgressClasses(ifaceName string) (*ClassState, error) {
+	ifb := GetIfbDeviceName(ifaceName)
+	link, err := netlink.LinkByName(ifb)
+	if err != nil {
+		if isNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("looking up IFB device %s: %w", ifb, err)
+	}
+	return readClasses(link)
+}
+
+func readClasses(link netlink.Link) (*ClassState, error) {
+	qdiscs, err := netlink.QdiscList(link)
+	if err != nil {
+		return nil, fmt.Errorf("listing qdiscs on %s: %w", link.Attrs().Name, err)
+	}
+	var root *netlink.Htb
+	for _, q := range qdiscs {
+		if htb, ok := q.(*netlink.Htb); ok && q.Attrs().Parent == netlink.HANDLE_ROOT {
+			root = htb
+			break
+		}
+	}
+	if root == nil {
+		return nil, nil
+	}
+
+	classesByHandle := map[uint32]string{}
+	state := &ClassState{}
+	classes, err := netlink.ClassList(link, root.Attrs().Handle)
+	if err != nil {
+		return nil, fmt.Errorf("listing classes on %s: %w", link.Attrs().Name, err)
+	}
+	for _, c := range classes {
+		htbClass, ok := c.(*netlink.HtbClass)
+		if !ok || htbClass.Attrs().Handle == root.Attrs().Handle {
+			continue
+		}
+		name := htbClass.Attrs().Name
+		classesByHandle[htbClass.Attrs().Handle] = name
+		state.Classes = append(state.Classes, ClassSpec{
+			Name:       name,
+			Guaranteed: htbClass.Rate,
+			Ceiling:    htbClass.Ceil,
+			Priority:   htbClass.Prio,
+		})
+	}
+
+	filters, err := netlink.FilterList(link, netlink.MakeHandle(classRootHandle, 0))
+	if err != nil {
+		return nil, fmt.Errorf("listing filters on %s: %w", link.Attrs().Name, err)
+	}
+	for _, f := range filters {
+		rule, ok := matchRuleFromFilter(f, classesByHandle)
+		if ok {
+			state.Rules = append(state.Rules, rule)
+		}
+	}
+	return state, nil
+}
+
+// CreateIngressClasses installs state as the HTB root and classes/filters shaping ifaceName's
+// ingress, replacing any existing root qdisc (TBF or HTB) on it.
+func CreateIngressClasses(state *ClassState, ifaceName string) error {
+	link, err := netlink.LinkByName(ifaceName)
+	if err != nil {
+		return fmt.Errorf("looking up interface %s: %w", ifaceName, err)
+	}
+	return applyClasses(link, state)
+}
+
+// UpdateIngressClasses replaces the existing HTB configuration on ifaceName with state.
+func UpdateIngressClasses(state *ClassState, ifaceName string) error {
+	return CreateIngressClasses(state, ifaceName)
+}
+
+// RemoveIngressClasses removes the HTB root (and with it, every child class and filter) from
+// ifaceName.
+func RemoveIngressClasses(ifaceName string) error {
+	link, err := netlink.LinkByName(ifaceName)
+	if err != nil {
+		if isNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("looking up interface %s: %w", ifaceName, err)
+	}
+	return removeHtbRoot(link)
+}
+
+// AddEgressClasses installs state as the HTB root and classes/filters shaping ifaceName's egress,
+// creating the backing IFB device and the redirect from ifaceName's egress to it if they don't
+// already exist, the same way AddEgressQdisc does for a flat TBF rate.
+func AddEgressClasses(state *ClassState, ifaceName string) error {
+	ifb := GetIfbDeviceName(ifaceName)
+	if err := ensureIfbDevice(ifb); err != nil {
+		return err
+	}
+	if err := ensureEgressRedirect(ifaceName, ifb); err != nil {
+		return err
+	}
+	link, err := netlink.LinkByName(ifb)
+	if err != nil {
+		return fmt.Errorf("looking up IFB device %s: %w", ifb, err)
+	}
+	return applyClasses(link, state)
+}
+
+// UpdateEgressClasses replaces the existing HTB configuration on ifbName (as returned by
+// GetIfbDeviceName) with state. The IFB device and redirect are assumed to already exist, since
+// egress classes are only ever updated after AddEgressClasses created them.
+func UpdateEgressClasses(state *ClassState, ifbName string) error {
+	link, err := netlink.LinkByName(ifbName)
+	if err != nil {
+		return fmt.Errorf("looking up IFB device %s: %w", ifbName, err)
+	}
+	return applyClasses(link, state)
+}
+
+// RemoveEgressClasses tears down the egress classes for ifaceName: the HTB root (and its classes
+// and filters), the redirect filter, and the IFB device itself, mirroring RemoveEgressQdisc.
+func RemoveEgressClasses(ifaceName string) error {
+	return RemoveEgressQdisc(ifaceName)
+}
+
+func applyClasses(link netlink.Link, state *ClassState) error {
+	// Deleting the root qdisc discards any previous classes/filters under it (a same-kind
+	// "replace" at the same handle would keep them, and a TBF root there would be refused), so
+	// the existing root is deleted and recreated first, then every class and filter rebuilt on
+	// top of it from scratch -- simpler and safer than diffing individual classes/filters in
+	// place, and cheap since this only runs when ClassState.Equals has already told the caller
+	// something changed.
+	if err := removeHtbRoot(link); err != nil {
+		return err
+	}
+	if err := removeTbf(link, netlink.HANDLE_ROOT); err != nil {
+		return err
+	}
+	root := &netlink.Htb{
+		QdiscAttrs: netlink.QdiscAttrs{
+			LinkIndex: link.Attrs().Index,
+			Handle:    netlink.MakeHandle(classRootHandle, 0),
+			Parent:    netlink.HANDLE_ROOT,
+		},
+		Defcls: 0,
+	}
+	if err := netlink.QdiscReplace(root); err != nil {
+		return fmt.Errorf("replacing HTB root on %s: %w", link.Attrs().Name, err)
+	}
+
+	handleByName := map[string]uint32{}
+	for i, c := range state.Classes {
+		minor := uint32(i + 1)
+		handle := netlink.MakeHandle(classRootHandle, minor)
+		handleByName[c.Name] = handle
+		ceil := c.Ceiling
+		if ceil == 0 {
+			ceil = c.Guaranteed
+		}
+		class := netlink.NewHtbClass(netlink.ClassAttrs{
+			LinkIndex: link.Attrs().Index,
+			Parent:    netlink.MakeHandle(classRootHandle, 0),
+			Handle:    handle,
+		}, netlink.HtbClassAttrs{
+			Rate: c.Guaranteed,
+			Ceil: ceil,
+			Prio: c.Priority,
+		})
+		class.Attrs().Name = c.Name
+		if err := netlink.ClassReplace(class); err != nil {
+			return fmt.Errorf("adding HTB class %s on %s: %w", c.Name, link.Attrs().Name, err)
+		}
+	}
+
+	for i, r := range state.Rules {
+		handle, ok := handleByName[r.ClassName]
+		if !ok {
+			return fmt.Errorf("match rule on %s references unknown class %q", link.Attrs().Name, r.ClassName)
+		}
+		filter := filterForMatchRule(link.Attrs().Index, uint16(i+1), r, handle)
+		if err := netlink.FilterReplace(filter); err != nil {
+			return fmt.Errorf("adding match rule for class %s on %s: %w", r.ClassName, link.Attrs().Name, err)
+		}
+	}
+	return nil
+}
+
+func removeHtbRoot(link netlink.Link) error {
+	qdiscs, err := netlink.QdiscList(link)
+	if err != nil {
+		return fmt.Errorf("listing qdiscs on %s: %w", link.Attrs().Name, err)
+	}
+	for _, q := range qdiscs {
+		if _, ok := q.(*netlink.Htb); !ok || q.Attrs().Parent != netlink.HANDLE_ROOT {
+			continue
+		}
+		if err := netlink.QdiscDel(q); err != nil {
+			return fmt.Errorf("deleting HTB root on %s: %w", link.Attrs().Name, err)
+		}
+	}
+	return nil
+}
+
+// filterForMatchRule builds the u32 (DSCP/L4) or fw (mark) filter for r, attached at the HTB
+// root's 1:0 handle with the given priority, classifying matching traffic to classHandle.
+func filterForMatchRule(linkIndex int, priority uint16, r MatchRule, classHandle uint32) netlink.Filter {
+	attrs := netlink.FilterAttrs{
+		LinkIndex: linkIndex,
+		Parent:    netlink.MakeHandle(classRootHandle, 0),
+		Priority:  priority,
+		Protocol:  unixETHAll,
+	}
+	if r.HasMark {
+		// The classic "fw" classifier matches packets whose fwmark equals the filter's own
+		// handle, so the mark to match on is carried in FilterAttrs.Handle rather than a
+		// separate field.
+		attrs.Handle = r.Mark
+		return &netlink.Fw{
+			FilterAttrs: attrs,
+			ClassId:     classHandle,
+		}
+	}
+	filter := &netlink.U32{FilterAttrs: attrs, ClassId: classHandle}
+	if r.HasDSCP {
+		// DSCP occupies the top 6 bits of the IP TOS byte, at offset 1 in the IPv4 header.
+		filter.Sel = &netlink.TcU32Sel{
+			Keys: []netlink.TcU32Key{{
+				Mask: 0x00fc0000,
+				Val:  uint32(r.DSCP) << 18,
+				Off:  0,
+			}},
+		}
+	}
+	if r.Protocol != 0 {
+		filter.Sel = appendU32Key(filter.Sel, netlink.TcU32Key{
+			Mask: 0x00ff0000,
+			Val:  uint32(r.Protocol) << 16,
+			Off:  8,
+		})
+	}
+	if r.Port != 0 {
+		filter.Sel = appendU32Key(filter.Sel, netlink.TcU32Key{
+			Mask: 0x0000ffff,
+			Val:  uint32(r.Port),
+			Off:  20,
+		})
+	}
+	return filter
+}
+
+func appendU32Key(sel *netlink.TcU32Sel, key netlink.TcU32Key) *netlink.TcU32Sel {
+	if sel == nil {
+		sel = &netlink.TcU32Sel{}
+	}
+	sel.Keys = append(sel.Keys, key)
+	return sel
+}
+
+// matchRuleFromFilter reverses filterForMatchRule well enough for read-diff-apply's comparison:
+// it only needs to reconstruct fields applyClasses itself sets, since a filter this package
+// didn't install is never present (the HTB root -- and everything under it -- is always fully
+// owned by this package, see applyClasses).
+func matchRuleFromFilter(f netlink.Filter, classesByHandle map[uint32]string) (MatchRule, bool) {
+	switch filter := f.(type) {
+	case *netlink.Fw:
+		name, ok := classesByHandle[filter.ClassId]
+		if !ok {
+			return MatchRule{}, false
+		}
+		return MatchRule{ClassName: name, HasMark: true, Mark: filter.Attrs().Handle}, true
+	case *netlink.U32:
+		name, ok := classesByHandle[filter.ClassId]
+		if !ok || filter.Sel == nil {
+			return MatchRule{}, false
+		}
+		rule := MatchRule{ClassName: name}
+		for _, key := range filter.Sel.Keys {
+			switch key.Off {
+			case 0:
+				rule.HasDSCP = true
+				rule.DSCP = uint8(key.Val >> 18)
+			case 8:
+				rule.Protocol = uint8(key.Val >> 16)
+			case 20:
+				rule.Port = uint16(key.Val)
+			}
+		}
+		return rule, true
+	default:
+		return MatchRule{}, false
+	}
+}
@@ -0,0 +1,363 @@
+// Copyright (c) 2025 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package qos installs and reconciles the token-bucket-filter (TBF) qdiscs that back per-workload
+// QoS bandwidth controls. Ingress shaping is attached directly to the workload's veth; egress
+// shaping can't be (Linux only lets you attach a classful qdisc, such as TBF, to the egress side
+// of a device, but ingress-side policing on the peer's egress is all a plain "ingress" qdisc
+// supports), so egress shaping is done by redirecting the workload's egress traffic to an IFB
+// (intermediate functional block) device and shaping that device's egress instead.
+package qos
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"syscall"
+
+	"github.com/vishvananda/netlink"
+)
+
+// ifbDevicePrefix is prepended to a workload interface's name to derive the name of the IFB
+// device that egress shaping for that workload is redirected through.
+const ifbDevicePrefix = "bwcali"
+
+// unixETHAll is ETH_P_ALL (0x0003) in network byte order, the protocol value "tc filter" uses to
+// mean "match every protocol" -- the same value the "tc" binary itself passes the kernel for a
+// protocol-less u32 filter.
+const unixETHAll = 0x0300
+
+// GetIfbDeviceName returns the name of the IFB device used to shape ifaceName's egress traffic.
+func GetIfbDeviceName(ifaceName string) string {
+	return ifbDevicePrefix + ifaceName
+}
+
+// TokenBucketState captures the parameters of a TBF qdisc, in the units the Linux kernel's TBF
+// implementation itself expects (bytes/s, bytes, ticks), so that a freshly read qdisc can be
+// compared directly against one freshly computed by GetTBFValues without a lossy unit conversion
+// on either side.
+type TokenBucketState struct {
+	// Rate is the sustained rate, in bytes per second, that the bucket refills at.
+	Rate uint64
+	// Burst is the size of the bucket, in bytes: the largest burst that can be sent at
+	// link speed before the rate limit starts throttling.
+	Burst uint32
+	// Peakrate bounds the instantaneous rate a burst can be sent at, in bytes per second. Zero
+	// means unbounded (limited only by the rate once the bucket is empty).
+	Peakrate uint64
+	// Minburst is the size, in bytes, of the second ("peak") bucket TBF uses to enforce
+	// Peakrate. Only meaningful when Peakrate is non-zero.
+	Minburst uint32
+}
+
+// Equals reports whether two TokenBucketStates describe the same qdisc configuration. A nil
+// receiver or argument is only equal to another nil, matching the "no QoS configured" case
+// maybeUpdateQoSBandwidth compares against.
+func (t *TokenBucketState) Equals(other *TokenBucketState) bool {
+	if t == nil || other == nil {
+		return t == nil && other == nil
+	}
+	return *t == *other
+}
+
+// GetTBFValues converts the bandwidth/burst/peakrate/minburst values from a WorkloadEndpoint's
+// QosControls (already in bytes/s and bytes) into a TokenBucketState ready to hand to
+// CreateIngressQdisc/AddEgressQdisc. A zero burst is filled in with a sane default (enough to
+// hold one round-trip at the given rate for this MTU's interrupt coalescing) rather than left at
+// zero, which the kernel's TBF implementation rejects.
+func GetTBFValues(bandwidth, burst, peakrate uint64, minburst uint32) *TokenBucketState {
+	if burst == 0 {
+		burst = defaultBurst(bandwidth)
+	}
+	return &TokenBucketState{
+		Rate:     bandwidth,
+		Burst:    clampUint32(burst),
+		Peakrate: peakrate,
+		Minburst: minburst,
+	}
+}
+
+// defaultBurst picks a burst size, in bytes, large enough that TBF's rate-limiting doesn't bite
+// into every single packet at typical MTUs, scaling with the configured rate the same way "tc"
+// itself recommends (rate / HZ, with a sensible floor).
+func defaultBurst(rate uint64) uint64 {
+	const minBurst = 4096
+	b := rate / 100
+	if b < minBurst {
+		return minBurst
+	}
+	return b
+}
+
+func clampUint32(v uint64) uint32 {
+	if v > math.MaxUint32 {
+		return math.MaxUint32
+	}
+	return uint32(v)
+}
+
+// ReadIngressQdisc returns the TBF state currently installed as the ingress (policing) qdisc on
+// ifaceName, or nil if none is installed.
+func ReadIngressQdisc(ifaceName string) (*TokenBucketState, error) {
+	return readTbf(ifaceName, netlink.HANDLE_ROOT)
+}
+
+// ReadEgressQdisc returns the TBF state currently installed as the egress qdisc on the IFB device
+// that ifaceName's egress traffic is redirected through, or nil if no such IFB device or qdisc
+// exists.
+func ReadEgressQdisc(ifaceName string) (*TokenBucketState, error) {
+	ifb := GetIfbDeviceName(ifaceName)
+	link, err := netlink.LinkByName(ifb)
+	if err != nil {
+		if isNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("looking up IFB device %s: %w", ifb, err)
+	}
+	return readTbfOnLink(link, netlink.HANDLE_ROOT)
+}
+
+func readTbf(ifaceName string, parent uint32) (*TokenBucketState, error) {
+	link, err := netlink.LinkByName(ifaceName)
+	if err != nil {
+		if isNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("looking up interface %s: %w", ifaceName, err)
+	}
+	return readTbfOnLink(link, parent)
+}
+
+func readTbfOnLink(link netlink.Link, parent uint32) (*TokenBucketState, error) {
+	qdiscs, err := netlink.QdiscList(link)
+	if err != nil {
+		return nil, fmt.Errorf("listing qdiscs on %s: %w", link.Attrs().Name, err)
+	}
+	for _, q := range qdiscs {
+		tbf, ok := q.(*netlink.Tbf)
+		if !ok || q.Attrs().Parent != parent {
+			continue
+		}
+		return &TokenBucketState{
+			Rate:     tbf.Rate,
+			Burst:    tbf.Buffer,
+			Peakrate: tbf.Peakrate,
+			Minburst: tbf.Minburst,
+		}, nil
+	}
+	return nil, nil
+}
+
+// CreateIngressQdisc installs state as the ingress qdisc on ifaceName. ifaceName must not already
+// have an ingress qdisc.
+func CreateIngressQdisc(state *TokenBucketState, ifaceName string) error {
+	link, err := netlink.LinkByName(ifaceName)
+	if err != nil {
+		return fmt.Errorf("looking up interface %s: %w", ifaceName, err)
+	}
+	return netlink.QdiscReplace(tbfQdisc(link.Attrs().Index, netlink.HANDLE_ROOT, state))
+}
+
+// RemoveIngressQdisc removes the ingress qdisc from ifaceName, if any.
+func RemoveIngressQdisc(ifaceName string) error {
+	link, err := netlink.LinkByName(ifaceName)
+	if err != nil {
+		if isNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("looking up interface %s: %w", ifaceName, err)
+	}
+	return removeTbf(link, netlink.HANDLE_ROOT)
+}
+
+// UpdateIngressQdisc replaces the existing ingress qdisc on ifaceName with state.
+func UpdateIngressQdisc(state *TokenBucketState, ifaceName string) error {
+	return CreateIngressQdisc(state, ifaceName)
+}
+
+// AddEgressQdisc installs state as the egress qdisc for ifaceName's egress traffic, creating the
+// backing IFB device and the redirect from ifaceName's egress to it if they don't already exist.
+func AddEgressQdisc(state *TokenBucketState, ifaceName string) error {
+	ifb := GetIfbDeviceName(ifaceName)
+	if err := ensureIfbDevice(ifb); err != nil {
+		return err
+	}
+	if err := ensureEgressRedirect(ifaceName, ifb); err != nil {
+		return err
+	}
+	link, err := netlink.LinkByName(ifb)
+	if err != nil {
+		return fmt.Errorf("looking up IFB device %s: %w", ifb, err)
+	}
+	return netlink.QdiscReplace(tbfQdisc(link.Attrs().Index, netlink.HANDLE_ROOT, state))
+}
+
+// UpdateEgressQdisc replaces the existing TBF qdisc on ifbName (as returned by GetIfbDeviceName)
+// with state. The IFB device and redirect are assumed to already exist, since an egress qdisc is
+// only ever updated after AddEgressQdisc created them.
+func UpdateEgressQdisc(state *TokenBucketState, ifbName string) error {
+	link, err := netlink.LinkByName(ifbName)
+	if err != nil {
+		return fmt.Errorf("looking up IFB device %s: %w", ifbName, err)
+	}
+	return netlink.QdiscReplace(tbfQdisc(link.Attrs().Index, netlink.HANDLE_ROOT, state))
+}
+
+// RemoveEgressQdisc tears down the egress shaping for ifaceName: the TBF qdisc, the redirect
+// filter, and the IFB device itself, so that no trace of it is left once QoS is disabled.
+func RemoveEgressQdisc(ifaceName string) error {
+	ifb := GetIfbDeviceName(ifaceName)
+	ifbLink, err := netlink.LinkByName(ifb)
+	if err != nil {
+		if isNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("looking up IFB device %s: %w", ifb, err)
+	}
+	if link, err := netlink.LinkByName(ifaceName); err == nil {
+		redirect := &netlink.U32{FilterAttrs: netlink.FilterAttrs{
+			LinkIndex: link.Attrs().Index,
+			Parent:    netlink.HANDLE_MIN_EGRESS,
+			Priority:  1,
+			Protocol:  unixETHAll,
+		}}
+		if err := netlink.FilterDel(redirect); err != nil && !errors.Is(err, syscall.ENOENT) {
+			return fmt.Errorf("removing egress redirect filter on %s: %w", ifaceName, err)
+		}
+	} else if !isNotFound(err) {
+		return fmt.Errorf("looking up interface %s: %w", ifaceName, err)
+	}
+	if err := netlink.LinkDel(ifbLink); err != nil {
+		return fmt.Errorf("deleting IFB device %s: %w", ifb, err)
+	}
+	return nil
+}
+
+func removeTbf(link netlink.Link, parent uint32) error {
+	qdiscs, err := netlink.QdiscList(link)
+	if err != nil {
+		return fmt.Errorf("listing qdiscs on %s: %w", link.Attrs().Name, err)
+	}
+	for _, q := range qdiscs {
+		if q.Attrs().Parent != parent {
+			continue
+		}
+		if _, ok := q.(*netlink.Tbf); !ok {
+			continue
+		}
+		if err := netlink.QdiscDel(q); err != nil {
+			return fmt.Errorf("deleting qdisc on %s: %w", link.Attrs().Name, err)
+		}
+	}
+	return nil
+}
+
+func tbfQdisc(linkIndex int, parent uint32, state *TokenBucketState) *netlink.Tbf {
+	return &netlink.Tbf{
+		QdiscAttrs: netlink.QdiscAttrs{
+			LinkIndex: linkIndex,
+			Handle:    netlink.MakeHandle(1, 0),
+			Parent:    parent,
+		},
+		Rate:     state.Rate,
+		Buffer:   state.Burst,
+		Peakrate: state.Peakrate,
+		Minburst: state.Minburst,
+	}
+}
+
+// ensureIfbDevice creates the IFB device named ifb, if it doesn't already exist, and brings it up.
+func ensureIfbDevice(ifb string) error {
+	if _, err := netlink.LinkByName(ifb); err == nil {
+		return nil
+	} else if !isNotFound(err) {
+		return fmt.Errorf("looking up IFB device %s: %w", ifb, err)
+	}
+	link := &netlink.Ifb{LinkAttrs: netlink.LinkAttrs{Name: ifb}}
+	if err := netlink.LinkAdd(link); err != nil {
+		return fmt.Errorf("creating IFB device %s: %w", ifb, err)
+	}
+	added, err := netlink.LinkByName(ifb)
+	if err != nil {
+		return fmt.Errorf("looking up newly-created IFB device %s: %w", ifb, err)
+	}
+	if err := netlink.LinkSetUp(added); err != nil {
+		return fmt.Errorf("bringing up IFB device %s: %w", ifb, err)
+	}
+	return nil
+}
+
+// ensureEgressRedirect installs a clsact qdisc and "mirred redirect" filter on ifaceName's egress
+// side, if not already present, sending every egress packet to ifb for shaping.
+func ensureEgressRedirect(ifaceName, ifb string) error {
+	link, err := netlink.LinkByName(ifaceName)
+	if err != nil {
+		return fmt.Errorf("looking up interface %s: %w", ifaceName, err)
+	}
+	ifbLink, err := netlink.LinkByName(ifb)
+	if err != nil {
+		return fmt.Errorf("looking up IFB device %s: %w", ifb, err)
+	}
+
+	if !hasClsact(link) {
+		clsact := &netlink.GenericQdisc{
+			QdiscAttrs: netlink.QdiscAttrs{
+				LinkIndex: link.Attrs().Index,
+				Handle:    netlink.MakeHandle(0xffff, 0),
+				Parent:    netlink.HANDLE_CLSACT,
+			},
+			QdiscType: "clsact",
+		}
+		if err := netlink.QdiscAdd(clsact); err != nil {
+			return fmt.Errorf("adding clsact qdisc to %s: %w", ifaceName, err)
+		}
+	}
+
+	filter := &netlink.U32{
+		FilterAttrs: netlink.FilterAttrs{
+			LinkIndex: link.Attrs().Index,
+			Parent:    netlink.HANDLE_MIN_EGRESS,
+			Priority:  1,
+			Protocol:  unixETHAll,
+		},
+		Actions: []netlink.Action{
+			&netlink.MirredAction{
+				ActionAttrs:  netlink.ActionAttrs{Action: netlink.TC_ACT_STOLEN},
+				MirredAction: netlink.TCA_EGRESS_REDIR,
+				Ifindex:      ifbLink.Attrs().Index,
+			},
+		},
+	}
+	if err := netlink.FilterReplace(filter); err != nil {
+		return fmt.Errorf("adding egress redirect filter on %s: %w", ifaceName, err)
+	}
+	return nil
+}
+
+func hasClsact(link netlink.Link) bool {
+	qdiscs, err := netlink.QdiscList(link)
+	if err != nil {
+		return false
+	}
+	for _, q := range qdiscs {
+		if _, ok := q.(*netlink.GenericQdisc); ok && q.Attrs().Parent == netlink.HANDLE_CLSACT {
+			return true
+		}
+	}
+	return false
+}
+
+func isNotFound(err error) bool {
+	return err == netlink.ErrLinkNotFound
+}
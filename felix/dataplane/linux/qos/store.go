@@ -0,0 +1,128 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package qos
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// PersistedState is the last QoS configuration this package successfully applied to one
+// workload's interface, as recorded in a Store. It's the reconciliation target for Store.LoadAll's
+// caller: live kernel state that doesn't match a PersistedState entry has drifted (an external
+// `tc` change, a half-applied update from a crash, an IFB device recreated by something else) and
+// should be repaired back to it; a PersistedState entry whose WorkloadEndpointID no longer
+// corresponds to a live workload is stale and should be garbage-collected instead.
+type PersistedState struct {
+	IfaceName          string `json:"ifaceName"`
+	WorkloadEndpointID string `json:"workloadEndpointID"`
+
+	Ingress    *TokenBucketState `json:"ingress,omitempty"`
+	IngressAQM *AQMState         `json:"ingressAQM,omitempty"`
+
+	Egress        *TokenBucketState `json:"egress,omitempty"`
+	EgressAQM     *AQMState         `json:"egressAQM,omitempty"`
+	IfbDeviceName string            `json:"ifbDeviceName,omitempty"`
+}
+
+// Store persists PersistedState entries under a directory (typically a "qos" subdirectory of
+// Felix's own state directory), one JSON file per workload, so they survive a Felix restart. It
+// has no in-memory cache of its own -- Save/Delete/LoadAll all go straight to disk -- since it's only
+// consulted at startup and on each reconcile pass, not on every dataplane read.
+type Store struct {
+	dir string
+}
+
+// NewStore builds a Store that persists entries under dir, creating dir (and any missing
+// parents) on first Save if it doesn't already exist.
+func NewStore(dir string) *Store {
+	return &Store{dir: dir}
+}
+
+// fileName derives a filesystem-safe file name from the (ifaceName, workloadEndpointID) pair a
+// PersistedState is keyed by. Both can contain '/' (a WorkloadEndpointID is typically
+// "namespace/pod-name"), which isn't valid in a single path segment, so it's replaced with '_';
+// the two sanitized parts are then joined with "--". Either part can itself contain "--" (or a
+// '_' that was not a '/'), so two different pairs can map to the same file name.
+func (s *Store) fileName(ifaceName, workloadEndpointID string) string {
+	sanitize := func(s string) string { return strings.ReplaceAll(s, "/", "_") }
+	return filepath.Join(s.dir, fmt.Sprintf("%s--%s.json", sanitize(ifaceName), sanitize(workloadEndpointID)))
+}
+
+// Save atomically (write-then-rename, as NetworkManagerReconciler.Apply does for its conf.d file)
+// writes state to disk, replacing any existing entry for the same (IfaceName,
+// WorkloadEndpointID).
+func (s *Store) Save(state PersistedState) error {
+	if err := os.MkdirAll(s.dir, 0o755); err != nil {
+		return fmt.Errorf("creating QoS state directory %s: %w", s.dir, err)
+	}
+	data, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("marshalling QoS state for %s: %w", state.IfaceName, err)
+	}
+	path := s.fileName(state.IfaceName, state.WorkloadEndpointID)
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0o644); err != nil {
+		return fmt.Errorf("writing QoS state file %s: %w", tmpPath, err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return fmt.Errorf("installing QoS state file %s: %w", path, err)
+	}
+	return nil
+}
+
+// Delete removes the persisted entry for (ifaceName, workloadEndpointID), if any. It's not an
+// error for no such entry to exist.
+func (s *Store) Delete(ifaceName, workloadEndpointID string) error {
+	err := os.Remove(s.fileName(ifaceName, workloadEndpointID))
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("removing QoS state file for %s: %w", ifaceName, err)
+	}
+	return nil
+}
+
+// LoadAll returns every PersistedState entry currently on disk, e.g. for a startup or periodic
+// reconcile pass to cross-check against live kernel state. A missing directory is treated as "no
+// entries" rather than an error, since that's exactly the state of a fresh install that's never
+// had any QoS configured.
+func (s *Store) LoadAll() ([]PersistedState, error) {
+	entries, err := os.ReadDir(s.dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading QoS state directory %s: %w", s.dir, err)
+	}
+	var result []PersistedState
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+		path := filepath.Join(s.dir, entry.Name())
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("reading QoS state file %s: %w", path, err)
+		}
+		var state PersistedState
+		if err := json.Unmarshal(data, &state); err != nil {
+			return nil, fmt.Errorf("parsing QoS state file %s: %w", path, err)
+		}
+		result = append(result, state)
+	}
+	return result, nil
+}
@@ -0,0 +1,141 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package intdataplane
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/projectcalico/calico/felix/dataplane/linux/qos"
+	"github.com/projectcalico/calico/felix/proto"
+)
+
+// classesFromQosControls builds the qos.ClassState qc describes for one direction, or nil if qc
+// doesn't configure any classes in that direction. classes is QosControls' IngressClasses or
+// EgressClasses and rules its IngressClassRules or EgressClassRules, which sit alongside the
+// flat-rate fields maybeUpdateQoSBandwidth already reads. A workload is configured with either a
+// flat rate or a set of classes in a given direction, never both (see classRootHandle in the qos
+// package), so maybeUpdateQoSClasses and maybeUpdateQoSBandwidth are mutually exclusive per
+// direction, picked by whether QosControls populates the flat-rate or the classes fields.
+func classesFromQosControls(classes []*proto.QoSClass, rules []*proto.QoSClassRule) *qos.ClassState {
+	if len(classes) == 0 {
+		return nil
+	}
+	state := &qos.ClassState{}
+	for _, c := range classes {
+		state.Classes = append(state.Classes, qos.ClassSpec{
+			Name:       c.Name,
+			Guaranteed: uint64(c.GuaranteedRate),
+			Ceiling:    uint64(c.CeilingRate),
+			Priority:   c.Priority,
+		})
+	}
+	for _, r := range rules {
+		state.Rules = append(state.Rules, qos.MatchRule{
+			ClassName: r.ClassName,
+			HasDSCP:   r.Dscp != 0,
+			DSCP:      uint8(r.Dscp),
+			Protocol:  uint8(r.Protocol),
+			Port:      uint16(r.Port),
+			HasMark:   r.Mark != 0,
+			Mark:      r.Mark,
+		})
+	}
+	return state
+}
+
+// maybeUpdateQoSClasses is maybeUpdateQoSBandwidth's sibling for hierarchical, class-based QoS:
+// instead of a single flat TBF rate, it reconciles an HTB root with named child classes and the
+// DSCP/L4/mark match rules that steer traffic into them, using the same read-diff-apply pattern
+// (and the same old-interface-removal handling on a rename) as maybeUpdateQoSBandwidth.
+func (m *endpointManager) maybeUpdateQoSClasses(old, new *proto.WorkloadEndpoint) error {
+	var errs []error
+
+	var oldName, newName string
+	if old != nil {
+		oldName = old.Name
+	}
+	if new != nil {
+		newName = new.Name
+	}
+
+	if old != nil && (oldName != newName) {
+		oldIngress, err := qos.ReadIngressClasses(oldName)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("error reading ingress classes from workload %s: %w", oldName, err))
+		}
+		if oldIngress != nil {
+			if err := qos.RemoveIngressClasses(oldName); err != nil {
+				errs = append(errs, fmt.Errorf("error removing ingress classes from workload %s: %w", oldName, err))
+			}
+		}
+		oldEgress, err := qos.ReadEgressClasses(oldName)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("error reading egress classes from workload %s: %w", oldName, err))
+		}
+		if oldEgress != nil {
+			if err := qos.RemoveEgressClasses(oldName); err != nil {
+				errs = append(errs, fmt.Errorf("error removing egress classes from workload %s: %w", oldName, err))
+			}
+		}
+	}
+
+	if new != nil {
+		var desiredIngress, desiredEgress *qos.ClassState
+		if new.QosControls != nil {
+			desiredIngress = classesFromQosControls(new.QosControls.IngressClasses, new.QosControls.IngressClassRules)
+			desiredEgress = classesFromQosControls(new.QosControls.EgressClasses, new.QosControls.EgressClassRules)
+		}
+
+		currentIngress, err := qos.ReadIngressClasses(newName)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("error reading ingress classes from workload %s: %w", newName, err))
+		}
+		currentEgress, err := qos.ReadEgressClasses(newName)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("error reading egress classes from workload %s: %w", newName, err))
+		}
+
+		if currentIngress == nil && desiredIngress != nil {
+			if err := qos.CreateIngressClasses(desiredIngress, newName); err != nil {
+				errs = append(errs, fmt.Errorf("error adding ingress classes to workload %s: %w", newName, err))
+			}
+		} else if currentIngress != nil && desiredIngress == nil {
+			if err := qos.RemoveIngressClasses(newName); err != nil {
+				errs = append(errs, fmt.Errorf("error removing ingress classes from workload %s: %w", newName, err))
+			}
+		} else if !currentIngress.Equals(desiredIngress) {
+			if err := qos.UpdateIngressClasses(desiredIngress, newName); err != nil {
+				errs = append(errs, fmt.Errorf("error changing ingress classes on workload %s: %w", newName, err))
+			}
+		}
+
+		if currentEgress == nil && desiredEgress != nil {
+			if err := qos.AddEgressClasses(desiredEgress, newName); err != nil {
+				errs = append(errs, fmt.Errorf("error adding egress classes to workload %s: %w", newName, err))
+			}
+		} else if currentEgress != nil && desiredEgress == nil {
+			if err := qos.RemoveEgressClasses(newName); err != nil {
+				errs = append(errs, fmt.Errorf("error removing egress classes from workload %s: %w", newName, err))
+			}
+		} else if !currentEgress.Equals(desiredEgress) {
+			if err := qos.UpdateEgressClasses(desiredEgress, qos.GetIfbDeviceName(newName)); err != nil {
+				errs = append(errs, fmt.Errorf("error changing egress classes on workload %s: %w", newName, err))
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -30,7 +30,65 @@ func (m *endpointManager) isQoSBandwidthSupported() bool {
 	return !m.bpfEnabled || (m.bpfEnabled && m.bpfAttachType == string(apiv3.BPFAttachOptionTCX) && tc.IsTcxSupported())
 }
 
-func (m *endpointManager) maybeUpdateQoSBandwidth(old, new *proto.WorkloadEndpoint) error {
+// ingressAQM and egressAQM build the AQM leaf qdisc state requested by qc for one direction, or
+// nil if qc doesn't ask for an AQM discipline in that direction. QosControls' IngressAqm/EgressAqm
+// kind selector ("fq_codel" or "cake") and the per-kind parameter fields (IngressFqCodelTarget,
+// IngressFqCodelInterval, IngressFqCodelQuantum, IngressFqCodelMemoryLimit, IngressFqCodelFlows,
+// IngressCakeOverhead, IngressCakeDiffserv, and their Egress equivalents) sit alongside the
+// existing IngressBandwidth etc. fields; CAKE's Bandwidth is always taken from the same rate the
+// TBF shaper for that direction uses, rather than a separate field, since CAKE is only ever
+// installed as a leaf underneath that same TBF here.
+func ingressAQM(qc *proto.QosControls) *qos.AQMState {
+	switch qc.IngressAqm {
+	case string(qos.AQMKindFqCodel):
+		return &qos.AQMState{
+			Kind:        qos.AQMKindFqCodel,
+			Target:      qc.IngressFqCodelTarget,
+			Interval:    qc.IngressFqCodelInterval,
+			Quantum:     qc.IngressFqCodelQuantum,
+			MemoryLimit: qc.IngressFqCodelMemoryLimit,
+			Flows:       qc.IngressFqCodelFlows,
+		}
+	case string(qos.AQMKindCake):
+		return &qos.AQMState{
+			Kind:      qos.AQMKindCake,
+			Bandwidth: uint64(qc.IngressBandwidth),
+			Overhead:  int(qc.IngressCakeOverhead),
+			Diffserv:  qc.IngressCakeDiffserv,
+		}
+	default:
+		return nil
+	}
+}
+
+func egressAQM(qc *proto.QosControls) *qos.AQMState {
+	switch qc.EgressAqm {
+	case string(qos.AQMKindFqCodel):
+		return &qos.AQMState{
+			Kind:        qos.AQMKindFqCodel,
+			Target:      qc.EgressFqCodelTarget,
+			Interval:    qc.EgressFqCodelInterval,
+			Quantum:     qc.EgressFqCodelQuantum,
+			MemoryLimit: qc.EgressFqCodelMemoryLimit,
+			Flows:       qc.EgressFqCodelFlows,
+		}
+	case string(qos.AQMKindCake):
+		return &qos.AQMState{
+			Kind:      qos.AQMKindCake,
+			Bandwidth: uint64(qc.EgressBandwidth),
+			Overhead:  int(qc.EgressCakeOverhead),
+			Diffserv:  qc.EgressCakeDiffserv,
+		}
+	default:
+		return nil
+	}
+}
+
+// maybeUpdateQoSBandwidth's wepID identifies the workload across an interface rename or
+// recreation (e.g. the "<namespace>/<workload-id>" form model.WorkloadEndpointKey uses) -- it's
+// threaded through purely so a successfully-applied result can be keyed in m.qosStore the same
+// way across restarts, and plays no part in deciding what QoS to apply.
+func (m *endpointManager) maybeUpdateQoSBandwidth(wepID string, old, new *proto.WorkloadEndpoint) error {
 	var errs []error
 
 	var oldName, newName string
@@ -44,7 +102,8 @@ func (m *endpointManager) maybeUpdateQoSBandwidth(old, new *proto.WorkloadEndpoi
 
 	if old != nil && (oldName != newName) {
 		// Interface name changed, or workload removed.  Remove ingress QoS, if present,
-		// from the old workload interface.
+		// from the old workload interface. The AQM leaf qdisc (if any) goes away along
+		// with its parent TBF qdisc, so there's nothing to remove separately here.
 		oldIngress, err := qos.ReadIngressQdisc(oldName)
 		if err != nil {
 			errs = append(errs, fmt.Errorf("error reading ingress qdisc from workload %s: %w", oldName, err))
@@ -65,18 +124,26 @@ func (m *endpointManager) maybeUpdateQoSBandwidth(old, new *proto.WorkloadEndpoi
 				errs = append(errs, fmt.Errorf("error removing egress qdisc from workload %s: %w", oldName, err))
 			}
 		}
+		if new == nil {
+			if err := m.recordQoSState(wepID, oldName, nil, nil, nil, nil); err != nil {
+				errs = append(errs, fmt.Errorf("error clearing persisted QoS state for workload %s: %w", oldName, err))
+			}
+		}
 	}
 
 	// Now we are only concerned with the new workload interface.
 	if new != nil {
 		// Work out what we QoS we want.
 		var desiredIngress, desiredEgress *qos.TokenBucketState
+		var desiredIngressAQM, desiredEgressAQM *qos.AQMState
 		if new.QosControls != nil {
 			if new.QosControls.IngressBandwidth != 0 {
 				desiredIngress = qos.GetTBFValues(uint64(new.QosControls.IngressBandwidth), uint64(new.QosControls.IngressBurst), uint64(new.QosControls.IngressPeakrate), uint32(new.QosControls.IngressMinburst))
+				desiredIngressAQM = ingressAQM(new.QosControls)
 			}
 			if new.QosControls.EgressBandwidth != 0 {
 				desiredEgress = qos.GetTBFValues(uint64(new.QosControls.EgressBandwidth), uint64(new.QosControls.EgressBurst), uint64(new.QosControls.EgressPeakrate), uint32(new.QosControls.EgressMinburst))
+				desiredEgressAQM = egressAQM(new.QosControls)
 			}
 		}
 
@@ -89,6 +156,14 @@ func (m *endpointManager) maybeUpdateQoSBandwidth(old, new *proto.WorkloadEndpoi
 		if err != nil {
 			errs = append(errs, fmt.Errorf("error reading egress qdisc from workload %s: %w", newName, err))
 		}
+		currentIngressAQM, err := qos.ReadIngressAQM(newName)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("error reading ingress AQM leaf qdisc from workload %s: %w", newName, err))
+		}
+		currentEgressAQM, err := qos.ReadEgressAQM(newName)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("error reading egress AQM leaf qdisc from workload %s: %w", newName, err))
+		}
 
 		if currentIngress == nil && desiredIngress != nil {
 			// Add.
@@ -129,6 +204,54 @@ func (m *endpointManager) maybeUpdateQoSBandwidth(old, new *proto.WorkloadEndpoi
 				errs = append(errs, fmt.Errorf("error changing egress qdisc on workload %s: %w", newName, err))
 			}
 		}
+
+		// Reconcile the AQM leaf qdisc underneath each TBF the same way, but only once the TBF
+		// itself is in the state we want it -- there's no leaf to attach to a TBF qdisc that
+		// doesn't exist yet, or that's about to be removed.
+		if desiredIngress != nil {
+			if currentIngressAQM == nil && desiredIngressAQM != nil {
+				err := qos.CreateIngressAQM(desiredIngressAQM, newName)
+				if err != nil {
+					errs = append(errs, fmt.Errorf("error adding ingress AQM leaf qdisc to workload %s: %w", newName, err))
+				}
+			} else if currentIngressAQM != nil && desiredIngressAQM == nil {
+				err := qos.RemoveIngressAQM(newName)
+				if err != nil {
+					errs = append(errs, fmt.Errorf("error removing ingress AQM leaf qdisc from workload %s: %w", newName, err))
+				}
+			} else if !currentIngressAQM.Equals(desiredIngressAQM) {
+				err := qos.UpdateIngressAQM(desiredIngressAQM, newName)
+				if err != nil {
+					errs = append(errs, fmt.Errorf("error changing ingress AQM leaf qdisc on workload %s: %w", newName, err))
+				}
+			}
+		}
+		if desiredEgress != nil {
+			if currentEgressAQM == nil && desiredEgressAQM != nil {
+				err := qos.CreateEgressAQM(desiredEgressAQM, newName)
+				if err != nil {
+					errs = append(errs, fmt.Errorf("error adding egress AQM leaf qdisc to workload %s: %w", newName, err))
+				}
+			} else if currentEgressAQM != nil && desiredEgressAQM == nil {
+				err := qos.RemoveEgressAQM(newName)
+				if err != nil {
+					errs = append(errs, fmt.Errorf("error removing egress AQM leaf qdisc from workload %s: %w", newName, err))
+				}
+			} else if !currentEgressAQM.Equals(desiredEgressAQM) {
+				err := qos.UpdateEgressAQM(desiredEgressAQM, newName)
+				if err != nil {
+					errs = append(errs, fmt.Errorf("error changing egress AQM leaf qdisc on workload %s: %w", newName, err))
+				}
+			}
+		}
+
+		// Persist what we now believe is applied, regardless of whether the above hit errors on
+		// some sub-part of it -- recordQoSState's job is to let the next restart's
+		// reconcileQoSState notice and repair exactly the gap between what's persisted and
+		// what's live, not to only persist on a perfectly clean apply.
+		if err := m.recordQoSState(wepID, newName, desiredIngress, desiredEgress, desiredIngressAQM, desiredEgressAQM); err != nil {
+			errs = append(errs, fmt.Errorf("error persisting QoS state for workload %s: %w", newName, err))
+		}
 	}
 
 	return errors.Join(errs...)
@@ -0,0 +1,159 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package intdataplane
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/projectcalico/calico/felix/dataplane/linux/qos"
+)
+
+// recordQoSState persists (or clears) maybeUpdateQoSBandwidth's just-applied result in
+// m.qosStore, so a future restart's reconcileQoSState has something to cross-check live kernel
+// state against. A nil qosStore (e.g. in a build/test that doesn't wire one up via
+// newEndpointManager) makes this a no-op rather than a panic, consistent with
+// maybeUpdateQoSBandwidth's own best-effort error handling.
+func (m *endpointManager) recordQoSState(wepID, ifaceName string, ingress, egress *qos.TokenBucketState, ingressAQM, egressAQM *qos.AQMState) error {
+	if m.qosStore == nil {
+		return nil
+	}
+	if ingress == nil && egress == nil {
+		return m.qosStore.Delete(ifaceName, wepID)
+	}
+	state := qos.PersistedState{
+		IfaceName:          ifaceName,
+		WorkloadEndpointID: wepID,
+		Ingress:            ingress,
+		IngressAQM:         ingressAQM,
+	}
+	if egress != nil {
+		state.Egress = egress
+		state.EgressAQM = egressAQM
+		state.IfbDeviceName = qos.GetIfbDeviceName(ifaceName)
+	}
+	return m.qosStore.Save(state)
+}
+
+// reconcileQoSState cross-checks every entry m.qosStore has on disk against live kernel state and
+// against liveWorkloads -- the set of workload endpoint IDs Felix currently knows about, mapped to
+// their current interface name -- repairing drift and garbage-collecting anything left behind by
+// a workload that no longer exists. newEndpointManager calls this once at construction time
+// (before the first normal per-endpoint reconcile, so a crash-induced mismatch doesn't linger
+// until the next incidental update to that workload). Nothing else in this package calls it, so
+// the store is not cross-checked against live state again until the next restart.
+func (m *endpointManager) reconcileQoSState(liveWorkloads map[string]string) error {
+	if m.qosStore == nil {
+		return nil
+	}
+	entries, err := m.qosStore.LoadAll()
+	if err != nil {
+		return fmt.Errorf("loading persisted QoS state: %w", err)
+	}
+
+	var errs []error
+	for _, entry := range entries {
+		currentIfaceName, stillLive := liveWorkloads[entry.WorkloadEndpointID]
+		if !stillLive {
+			if err := m.gcQoSState(entry); err != nil {
+				errs = append(errs, err)
+			}
+			continue
+		}
+		if currentIfaceName != entry.IfaceName {
+			// The workload's interface was renamed (or recreated) while Felix was down.
+			// There's nothing meaningful to repair against the old name; clear it and
+			// let the normal per-endpoint reconcile (which always runs against the
+			// current WorkloadEndpoint) apply QoS afresh under the new name.
+			if err := m.gcQoSState(entry); err != nil {
+				errs = append(errs, err)
+			}
+			continue
+		}
+		if err := m.repairQoSDrift(entry); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// gcQoSState removes whatever live kernel state entry describes (the ingress qdisc on its
+// interface, and the IFB device and everything on it for its egress side) and its persisted
+// entry, for a workload that no longer exists.
+func (m *endpointManager) gcQoSState(entry qos.PersistedState) error {
+	var errs []error
+	if entry.Ingress != nil {
+		if err := qos.RemoveIngressQdisc(entry.IfaceName); err != nil {
+			errs = append(errs, fmt.Errorf("error removing stale ingress qdisc from %s: %w", entry.IfaceName, err))
+		}
+	}
+	if entry.Egress != nil {
+		if err := qos.RemoveEgressQdisc(entry.IfaceName); err != nil {
+			errs = append(errs, fmt.Errorf("error removing stale egress qdisc/IFB device for %s: %w", entry.IfaceName, err))
+		}
+	}
+	if err := m.qosStore.Delete(entry.IfaceName, entry.WorkloadEndpointID); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+// repairQoSDrift compares entry -- the last QoS state this package successfully applied -- against
+// what's actually live on entry.IfaceName, and re-applies entry's values wherever they differ. It
+// never consults the workload's current proto.WorkloadEndpoint; if policy has changed what QoS
+// that workload wants since entry was persisted, the normal maybeUpdateQoSBandwidth path (driven
+// by the next datamodel update) is what reconciles that, the same as it always has. This function
+// only concerns itself with live state having silently diverged from what Felix last believed it
+// applied. An AQM leaf is re-applied only together with a TBF qdisc that drifted; a leaf that
+// changed underneath a TBF that still matches entry is not detected.
+func (m *endpointManager) repairQoSDrift(entry qos.PersistedState) error {
+	var errs []error
+
+	liveIngress, err := qos.ReadIngressQdisc(entry.IfaceName)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("error reading ingress qdisc from %s during reconcile: %w", entry.IfaceName, err))
+	} else if !liveIngress.Equals(entry.Ingress) && entry.Ingress != nil {
+		if err := qos.UpdateIngressQdisc(entry.Ingress, entry.IfaceName); err != nil {
+			errs = append(errs, fmt.Errorf("error repairing ingress qdisc on %s: %w", entry.IfaceName, err))
+		} else if entry.IngressAQM != nil {
+			if err := qos.UpdateIngressAQM(entry.IngressAQM, entry.IfaceName); err != nil {
+				errs = append(errs, fmt.Errorf("error repairing ingress AQM leaf qdisc on %s: %w", entry.IfaceName, err))
+			}
+		}
+	}
+
+	if entry.Egress == nil {
+		return errors.Join(errs...)
+	}
+	liveEgress, err := qos.ReadEgressQdisc(entry.IfaceName)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("error reading egress qdisc from %s during reconcile: %w", entry.IfaceName, err))
+		return errors.Join(errs...)
+	}
+	if liveEgress.Equals(entry.Egress) {
+		return errors.Join(errs...)
+	}
+	// Either the TBF qdisc drifted, or the IFB device itself is gone (e.g. something else on
+	// the host recreated or removed it) -- AddEgressQdisc handles both, since it (re)creates
+	// the IFB device and redirect if missing before (re)installing the TBF qdisc.
+	if err := qos.AddEgressQdisc(entry.Egress, entry.IfaceName); err != nil {
+		errs = append(errs, fmt.Errorf("error repairing egress qdisc/IFB device for %s: %w", entry.IfaceName, err))
+	} else if entry.EgressAQM != nil {
+		if err := qos.UpdateEgressAQM(entry.EgressAQM, entry.IfaceName); err != nil {
+			errs = append(errs, fmt.Errorf("error repairing egress AQM leaf qdisc for %s: %w", entry.IfaceName, err))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,118 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package proto holds the calculation-graph message types Felix's dataplane driver and other
+// in-process consumers (the collector, the config package) exchange. Felix's real felix/proto is
+// generated by protoc-gen-go from felixbackend.proto; this checkout doesn't vendor the protoc
+// toolchain or the .proto sources it would generate from, so this package is hand-maintained,
+// covering only the messages and fields the rest of this tree actually references.
+package proto
+
+// WorkloadEndpoint is a calculation-graph WorkloadEndpoint, as delivered to Felix's dataplane
+// driver and to in-process consumers like the collector.
+type WorkloadEndpoint struct {
+	Name        string
+	Labels      map[string]string
+	Annotations map[string]string
+	Tiers       []*TierInfo
+	QosControls *QosControls
+}
+
+// TierInfo names a tier and, in match order, the policies within it that apply to the
+// WorkloadEndpoint/HostEndpoint it's attached to.
+type TierInfo struct {
+	Name            string
+	IngressPolicies []string
+	EgressPolicies  []string
+}
+
+// Rule is one match/action entry within a Policy's InboundRules/OutboundRules.
+type Rule struct {
+	Action     string
+	Conditions []*RuleCondition
+}
+
+// RuleCondition is one label/principal/counter predicate attached to a Rule, evaluated by
+// felix/collector/chain against a candidate packet beyond the plain L3/L4 tuple match Action
+// alone already covers.
+type RuleCondition struct {
+	Op     string
+	Key    string
+	Values []string
+}
+
+// QosControls carries the bandwidth-shaping parameters the calculation graph has resolved for
+// one WorkloadEndpoint's ingress/egress traffic.
+type QosControls struct {
+	IngressBandwidth int64
+	IngressBurst     int64
+	IngressPeakrate  int64
+	IngressMinburst  uint32
+
+	EgressBandwidth int64
+	EgressBurst     int64
+	EgressPeakrate  int64
+	EgressMinburst  uint32
+
+	// IngressAqm/EgressAqm select the active queue management discipline ("fq_codel" or
+	// "cake") installed as a leaf qdisc underneath the ingress/egress TBF shaper; an empty
+	// string means no AQM leaf. The FqCodel*/Cake* fields below are only meaningful for the
+	// corresponding Aqm selection.
+	IngressAqm                string
+	IngressFqCodelTarget      uint32
+	IngressFqCodelInterval    uint32
+	IngressFqCodelQuantum     uint32
+	IngressFqCodelMemoryLimit uint32
+	IngressFqCodelFlows       uint32
+	IngressCakeOverhead       int32
+	IngressCakeDiffserv       string
+
+	EgressAqm                string
+	EgressFqCodelTarget      uint32
+	EgressFqCodelInterval    uint32
+	EgressFqCodelQuantum     uint32
+	EgressFqCodelMemoryLimit uint32
+	EgressFqCodelFlows       uint32
+	EgressCakeOverhead       int32
+	EgressCakeDiffserv       string
+
+	// IngressClasses/EgressClasses and IngressClassRules/EgressClassRules configure
+	// hierarchical, class-based QoS instead of a single flat rate; a WorkloadEndpoint is
+	// configured with either the flat-rate fields above or these, never both, in a given
+	// direction.
+	IngressClasses    []*QoSClass
+	IngressClassRules []*QoSClassRule
+	EgressClasses     []*QoSClass
+	EgressClassRules  []*QoSClassRule
+}
+
+// QoSClass is one named HTB class within a WorkloadEndpoint's IngressClasses/EgressClasses: a
+// guaranteed and ceiling rate (both bytes/s), and a borrow Priority (lower value wins), matching
+// qos.ClassSpec's fields.
+type QoSClass struct {
+	Name           string
+	GuaranteedRate int64
+	CeilingRate    int64
+	Priority       uint32
+}
+
+// QoSClassRule steers traffic into a QoSClass by DSCP and/or (Protocol, Port) and/or fwmark,
+// matching qos.MatchRule's fields.
+type QoSClassRule struct {
+	ClassName string
+	Dscp      uint32
+	Protocol  uint32
+	Port      uint32
+	Mark      uint32
+}
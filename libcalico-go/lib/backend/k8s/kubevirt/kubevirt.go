@@ -3,7 +3,10 @@ package kubevirt
 import (
 	"fmt"
 	"reflect"
+	"time"
 
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
 	kubevirtclient "kubevirt.io/client-go/kubevirt/typed/core/v1"
 
 	"github.com/projectcalico/calico/libcalico-go/lib/apiconfig"
@@ -14,7 +17,26 @@ import (
 	"github.com/projectcalico/calico/libcalico-go/lib/backend/model"
 )
 
+// vmimInformerResyncPeriod is how often the VMIM informer this package builds for itself (when
+// Enable isn't given a shared one) falls back to a full relist, as a safety net against missed
+// watch events rather than the primary sync mechanism.
+const vmimInformerResyncPeriod = 10 * time.Minute
+
+// Enable is the same as EnableWithVMIMInformer with a nil vmimInformer: it builds a private VMIM
+// informer scoped to this call, so existing callers get this chunk's relist-free restart benefit
+// without having to plumb a shared informer through themselves.
 func Enable(client api.Client, ca *apiconfig.CalicoAPIConfigSpec) error {
+	return EnableWithVMIMInformer(client, ca, nil)
+}
+
+// EnableWithVMIMInformer is Enable, but lets callers pass a cache.SharedIndexInformer for
+// VirtualMachineInstanceMigrations that's shared across every Calico component enabling KubeVirt
+// in this process (Felix, confd, kube-controllers), built once via resources.NewVMIMSharedInformer
+// and started by the caller. This avoids each component's own LiveMigrationClient issuing a
+// duplicate List+Watch against the APIserver, and avoids a full List on every syncer restart,
+// since List/Watch then read from the informer's already-populated local cache/delta FIFO. If
+// vmimInformer is nil, a private one is built and started for the lifetime of the process instead.
+func EnableWithVMIMInformer(client api.Client, ca *apiconfig.CalicoAPIConfigSpec, vmimInformer cache.SharedIndexInformer) error {
 	c, ok := client.(*k8s.KubeClient)
 	if !ok {
 		return fmt.Errorf("%v is not a KubeClient", client)
@@ -27,12 +49,53 @@ func Enable(client api.Client, ca *apiconfig.CalicoAPIConfigSpec) error {
 	if err != nil {
 		return fmt.Errorf("failed to build KubeVirt client: %v", err)
 	}
+
+	rawVMIMClient := func(namespace string) resources.VMIMClient {
+		return kvClient.VirtualMachineInstanceMigrations(namespace)
+	}
+	if vmimInformer == nil {
+		vmimInformer = resources.NewVMIMSharedInformer(rawVMIMClient, vmimInformerResyncPeriod)
+		go vmimInformer.Run(wait.NeverStop)
+		if !cache.WaitForCacheSync(wait.NeverStop, vmimInformer.HasSynced) {
+			return fmt.Errorf("failed to sync VirtualMachineInstanceMigration informer")
+		}
+	}
+
 	c.RegisterResourceClient(
 		reflect.TypeOf(model.ResourceKey{}),
 		reflect.TypeOf(model.ResourceListOptions{}),
 		internalapi.KindLiveMigration,
-		resources.NewLiveMigrationClient(func(namespace string) resources.VMIMClient {
-			return kvClient.VirtualMachineInstanceMigrations(namespace)
+		resources.NewLiveMigrationClient(
+			func(namespace string) resources.VMIMClient {
+				return resources.NewInformerBackedVMIMClient(vmimInformer, namespace)
+			},
+			resources.WithVMIMWriter(func(namespace string) resources.VMIMWriter {
+				return kvClient.VirtualMachineInstanceMigrations(namespace)
+			}),
+			resources.WithVMIClient(func(namespace string) resources.VMIClient {
+				return kvClient.VirtualMachineInstances(namespace)
+			}),
+		),
+	)
+
+	// Also register VirtualMachine and VirtualMachineInstance as read-only resource kinds, so
+	// that a syncer consumer (see felix/calc's KubeVirtVMWatcher) can watch VM metadata and
+	// migration-time VMI renames directly, rather than only seeing the launcher pod's
+	// short-lived WorkloadEndpoint labels.
+	c.RegisterResourceClient(
+		reflect.TypeOf(model.ResourceKey{}),
+		reflect.TypeOf(model.ResourceListOptions{}),
+		internalapi.KindKubeVirtVM,
+		resources.NewVirtualMachineClient(func(namespace string) resources.VMClient {
+			return kvClient.VirtualMachines(namespace)
+		}),
+	)
+	c.RegisterResourceClient(
+		reflect.TypeOf(model.ResourceKey{}),
+		reflect.TypeOf(model.ResourceListOptions{}),
+		internalapi.KindKubeVirtVMI,
+		resources.NewVirtualMachineInstanceClient(func(namespace string) resources.VMIClient {
+			return kvClient.VirtualMachineInstances(namespace)
 		}),
 	)
 	return nil
@@ -500,6 +500,14 @@ func (c *customK8sResourceClient) convertResourceToKVPair(r Resource) (*model.KV
 		},
 		Revision: r.GetObjectMeta().GetResourceVersion(),
 	}
+	if uid := r.GetObjectMeta().GetUID(); uid != "" {
+		// Populate kvp.UID from the object we just fetched/created/listed, the mirror image of
+		// convertKVPairToResource setting ObjectMeta.UID from kvp.UID before a PUT. Without this,
+		// a kvp round-tripped through Get (e.g. UpdateWithRetry's get-modify-put loop) never
+		// carries a UID forward, so the optimistic-concurrency precondition Update/UpdateStatus
+		// thread through to the PUT can never fire.
+		kvp.UID = &uid
+	}
 
 	if err := ConvertK8sResourceToCalicoResource(r); err != nil {
 		return kvp, err
@@ -512,6 +520,17 @@ func (c *customK8sResourceClient) convertResourceToKVPair(r Resource) (*model.KV
 func (c *customK8sResourceClient) convertKVPairToResource(kvp *model.KVPair) (Resource, error) {
 	resource := kvp.Value.(Resource)
 	resource.GetObjectMeta().SetResourceVersion(kvp.Revision)
+	if kvp.UID != nil {
+		// Thread the optimistic-concurrency precondition Delete already honors through to
+		// Update/UpdateStatus too: setting ObjectMeta.UID here makes the PUT in Update fail
+		// fast with a conflict if the object was deleted and recreated under the same name,
+		// rather than silently overwriting an unrelated object that happens to share it.
+		uid, err := conversion.ConvertUID(*kvp.UID)
+		if err != nil {
+			return nil, err
+		}
+		resource.GetObjectMeta().SetUID(uid)
+	}
 	resOut, err := ConvertCalicoResourceToK8sResource(resource)
 	if err != nil {
 		return resOut, err
@@ -0,0 +1,160 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/model"
+)
+
+// applyPatchContentType is the content type the Kubernetes API server requires for a Server-Side
+// Apply request; there's no types.PatchType constant for it, apply is selected by content type
+// rather than by the patch body's shape the way JSON/merge/strategic-merge patches are.
+const applyPatchContentType = "application/apply-patch+yaml"
+
+// ApplyOptions configures a customK8sResourceClient.Apply call.
+type ApplyOptions struct {
+	// FieldManager identifies the caller to the API server's server-side-apply field tracking,
+	// e.g. "calico-kube-controllers" or "calicoctl". Required: the API server rejects an apply
+	// patch with no field manager.
+	FieldManager string
+	// Force takes ownership of fields currently owned by another manager instead of returning a
+	// conflict for them. Equivalent to `kubectl apply --force-conflicts`.
+	Force bool
+}
+
+// FieldManagerConflictError is returned by Apply when the API server rejects the patch because
+// fields it would touch are owned by other managers and Force wasn't set. Managers lists the
+// field managers the server reported as conflicting, taken from the returned
+// metav1.Status.Details.Causes, so a caller can decide whether to retry with Force or back off.
+type FieldManagerConflictError struct {
+	Managers []string
+	cause    error
+}
+
+func (e *FieldManagerConflictError) Error() string {
+	return fmt.Sprintf("conflicts with field manager(s) %v: %s", e.Managers, e.cause)
+}
+
+func (e *FieldManagerConflictError) Unwrap() error {
+	return e.cause
+}
+
+// Apply creates or updates a Custom K8s Resource instance using a Kubernetes Server-Side Apply
+// patch, rather than Create/Update's POST/PUT full-object replacement. Because SSA tracks field
+// ownership per manager, an Apply call only takes ownership of the fields present in kvp.Value,
+// leaving fields other controllers or an admission mutator have set on the object untouched.
+func (c *customK8sResourceClient) Apply(ctx context.Context, kvp *model.KVPair, opts ApplyOptions) (*model.KVPair, error) {
+	logContext := log.WithFields(log.Fields{
+		"Key":          kvp.Key,
+		"Value":        kvp.Value,
+		"Resource":     c.resource,
+		"FieldManager": opts.FieldManager,
+	})
+	logContext.Debug("Apply custom Kubernetes resource")
+
+	if opts.FieldManager == "" {
+		return nil, fmt.Errorf("field manager is required for Apply")
+	}
+
+	resIn, err := c.convertKVPairToResource(kvp)
+	if err != nil {
+		logContext.WithError(err).Debug("Error converting to k8s resource")
+		return nil, err
+	}
+	if c.validator != nil {
+		if err = c.validator.Validate(resIn); err != nil {
+			logContext.WithError(err).Debug("Error applying resource")
+			return nil, err
+		}
+	}
+
+	name := c.defaultPolicyName(resIn.GetObjectMeta().GetName())
+	namespace := resIn.GetObjectMeta().GetNamespace()
+	logContext = logContext.WithField("Name", name)
+	logContext.Debug("Apply resource by name")
+
+	resOut := reflect.New(c.k8sResourceType).Interface().(Resource)
+	req := c.restClient.Patch(types.PatchType(applyPatchContentType)).
+		NamespaceIfScoped(namespace, c.namespaced).
+		Resource(c.resource).
+		Name(name).
+		Param("fieldManager", opts.FieldManager).
+		Body(resIn)
+	if opts.Force {
+		req = req.Param("force", "true")
+	}
+	err = req.Do(ctx).Into(resOut)
+	if err != nil {
+		if conflict := fieldManagerConflictFrom(err); conflict != nil {
+			return nil, conflict
+		}
+		logContext.WithError(err).Debug("Error applying resource")
+		return nil, K8sErrorToCalico(err, kvp.Key)
+	}
+
+	kvp, err = c.convertResourceToKVPair(resOut)
+	if err != nil {
+		logContext.WithError(err).Debug("Error converting applied K8s resource to Calico resource")
+		return nil, K8sErrorToCalico(err, kvp.Key)
+	}
+	kvp.Revision = resOut.GetObjectMeta().GetResourceVersion()
+
+	return kvp, nil
+}
+
+// fieldManagerConflictFrom returns a *FieldManagerConflictError listing the conflicting field
+// managers named in err's metav1.Status.Details.Causes, or nil if err isn't a conflict the API
+// server attributed to specific managers.
+func fieldManagerConflictFrom(err error) *FieldManagerConflictError {
+	if !kerrors.IsConflict(err) {
+		return nil
+	}
+	statusErr, ok := err.(*kerrors.StatusError)
+	if !ok || statusErr.ErrStatus.Details == nil {
+		return nil
+	}
+	var managers []string
+	for _, cause := range statusErr.ErrStatus.Details.Causes {
+		if cause.Type != metav1.CauseTypeFieldManagerConflict {
+			continue
+		}
+		// cause.Field is the conflicting field's path; the manager is named, quoted, in
+		// cause.Message ("conflict with \"<manager>\"", optionally followed by "using <version>").
+		rest, ok := strings.CutPrefix(cause.Message, "conflict with ")
+		if !ok {
+			continue
+		}
+		if quoted, err := strconv.QuotedPrefix(rest); err == nil {
+			if name, err := strconv.Unquote(quoted); err == nil {
+				managers = append(managers, name)
+			}
+		}
+	}
+	if len(managers) == 0 {
+		return nil
+	}
+	return &FieldManagerConflictError{Managers: managers, cause: err}
+}
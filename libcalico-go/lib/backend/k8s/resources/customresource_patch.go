@@ -0,0 +1,81 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"context"
+	"reflect"
+
+	log "github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/model"
+)
+
+// Patch sends a JSON patch (types.JSONPatchType), a JSON merge patch (types.MergePatchType), or a
+// strategic merge patch (types.StrategicMergePatchType) for key, letting a caller update a single
+// field -- e.g. one condition in a BGPPeer or IPPool status -- without the read-modify-write cycle
+// Update/UpdateStatus require. patchType selects which of the three data is encoded as.
+func (c *customK8sResourceClient) Patch(ctx context.Context, key model.Key, patchType types.PatchType, data []byte) (*model.KVPair, error) {
+	return c.patch(ctx, key, patchType, data, "")
+}
+
+// PatchStatus is Patch's subresource variant, mirroring UpdateStatus: it sends the patch to
+// key's "status" subresource instead of the resource itself.
+func (c *customK8sResourceClient) PatchStatus(ctx context.Context, key model.Key, patchType types.PatchType, data []byte) (*model.KVPair, error) {
+	return c.patch(ctx, key, patchType, data, "status")
+}
+
+func (c *customK8sResourceClient) patch(ctx context.Context, key model.Key, patchType types.PatchType, data []byte, subResource string) (*model.KVPair, error) {
+	logContext := log.WithFields(log.Fields{
+		"Key":         key,
+		"Resource":    c.resource,
+		"PatchType":   patchType,
+		"SubResource": subResource,
+	})
+	logContext.Debug("Patch custom Kubernetes resource")
+
+	name, err := c.keyToName(key)
+	if err != nil {
+		logContext.WithError(err).Debug("Error patching resource")
+		return nil, err
+	}
+	name = c.defaultPolicyName(name)
+	namespace := key.(model.ResourceKey).Namespace
+
+	req := c.restClient.Patch(patchType).
+		NamespaceIfScoped(namespace, c.namespaced).
+		Resource(c.resource).
+		Name(name).
+		Body(data)
+	if subResource != "" {
+		req = req.SubResource(subResource)
+	}
+
+	resOut := reflect.New(c.k8sResourceType).Interface().(Resource)
+	if err := req.Do(ctx).Into(resOut); err != nil {
+		logContext.WithError(err).Debug("Error patching resource")
+		return nil, K8sErrorToCalico(err, key)
+	}
+
+	kvp, err := c.convertResourceToKVPair(resOut)
+	if err != nil {
+		logContext.WithError(err).Debug("Error converting patched K8s resource to Calico resource")
+		return nil, err
+	}
+	kvp.Revision = resOut.GetObjectMeta().GetResourceVersion()
+
+	return kvp, nil
+}
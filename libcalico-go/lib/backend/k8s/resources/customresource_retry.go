@@ -0,0 +1,85 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/model"
+)
+
+const (
+	// defaultRetryAttempts is used when UpdateWithRetry is called with attempts <= 0.
+	defaultRetryAttempts = 5
+	retryBaseBackoff     = 50 * time.Millisecond
+	retryMaxBackoff      = 2 * time.Second
+)
+
+// UpdateWithRetry implements the get-modify-put retry loop customK8sResourceClient's callers
+// (kube-controllers, felix, calicoctl) have each had to hand-write against Update's raw conflict
+// error: it fetches the current object, applies mutate to it, calls Update, and on a conflict
+// re-fetches and retries up to attempts times with jittered backoff -- the same pattern as
+// client-go's retry.RetryOnConflict. Because it calls Update rather than PUTting mutate's result
+// directly, Update's validation and convertKVPairToResource's conversion still run on every
+// attempt, against the freshly-fetched object, not just the caller's original. attempts <= 0
+// uses defaultRetryAttempts.
+func (c *customK8sResourceClient) UpdateWithRetry(ctx context.Context, key model.Key, mutate func(Resource) error, attempts int) (*model.KVPair, error) {
+	if attempts <= 0 {
+		attempts = defaultRetryAttempts
+	}
+
+	var lastErr error
+	for attempt := 0; attempt < attempts; attempt++ {
+		kvp, err := c.Get(ctx, key, "")
+		if err != nil {
+			return nil, err
+		}
+
+		if err := mutate(kvp.Value.(Resource)); err != nil {
+			return nil, err
+		}
+
+		updated, err := c.Update(ctx, kvp)
+		if err == nil {
+			return updated, nil
+		}
+		if !kerrors.IsConflict(err) {
+			return nil, err
+		}
+		lastErr = err
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(retryBackoff(attempt)):
+		}
+	}
+	return nil, lastErr
+}
+
+// retryBackoff returns a jittered, exponentially-growing delay for the given zero-based retry
+// attempt, capped at retryMaxBackoff so a long run of conflicts doesn't end up waiting minutes
+// between tries.
+func retryBackoff(attempt int) time.Duration {
+	backoff := retryBaseBackoff * time.Duration(1<<uint(attempt))
+	if backoff > retryMaxBackoff {
+		backoff = retryMaxBackoff
+	}
+	return backoff/2 + time.Duration(rand.Int63n(int64(backoff/2)+1))
+}
@@ -0,0 +1,163 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	apiv3 "github.com/projectcalico/api/pkg/apis/projectcalico/v3"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/api"
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/model"
+)
+
+// Condition is a readiness predicate Wait polls for: it reports whether kvp is "ready" by the
+// caller's definition, or an error if kvp can't be evaluated at all (a malformed resource, say --
+// returning an error here stops Wait immediately instead of waiting out the full timeout).
+type Condition func(kvp *model.KVPair) (bool, error)
+
+// DefaultConditions are the built-in Conditions Wait's callers get by resourceKind without
+// having to write their own, modeled on the readiness checks Helm's kube client applies before
+// considering a release's resources "up": IPPool is ready once it has allocated at least one
+// block, BGPPeer once at least one session has established, and KubeControllersConfiguration
+// once its reported RunningConfig has converged with the requested Spec.
+//
+// NetworkPolicy/GlobalNetworkPolicy readiness (waiting for at least one Felix instance to report
+// the policy in its FelixConfiguration status) needs a second resource lookup per poll rather
+// than just inspecting the KVPair Wait is already watching, so it isn't included here; it's
+// follow-up work once Wait grows a variant that's handed a client to do that lookup with.
+var DefaultConditions = map[string]Condition{
+	apiv3.KindIPPool:                       ipPoolAllocated,
+	apiv3.KindBGPPeer:                      bgpPeerEstablished,
+	apiv3.KindKubeControllersConfiguration: kubeControllersConverged,
+}
+
+func ipPoolAllocated(kvp *model.KVPair) (bool, error) {
+	pool, ok := kvp.Value.(*apiv3.IPPool)
+	if !ok {
+		return false, fmt.Errorf("expected *apiv3.IPPool, got %T", kvp.Value)
+	}
+	return len(pool.Status.AllocationBlocks) > 0, nil
+}
+
+func bgpPeerEstablished(kvp *model.KVPair) (bool, error) {
+	peer, ok := kvp.Value.(*apiv3.BGPPeer)
+	if !ok {
+		return false, fmt.Errorf("expected *apiv3.BGPPeer, got %T", kvp.Value)
+	}
+	return peer.Status.NumEstablishedPeers >= 1, nil
+}
+
+func kubeControllersConverged(kvp *model.KVPair) (bool, error) {
+	kcc, ok := kvp.Value.(*apiv3.KubeControllersConfiguration)
+	if !ok {
+		return false, fmt.Errorf("expected *apiv3.KubeControllersConfiguration, got %T", kvp.Value)
+	}
+	// KubeControllersConfigurationSpec nests pointer-typed per-controller config structs, so a
+	// plain == here would compare pointer identity on those fields rather than their contents --
+	// two independently-unmarshaled values that are logically converged would almost never
+	// satisfy it. reflect.DeepEqual compares the structs themselves.
+	return reflect.DeepEqual(kcc.Status.RunningConfig, kcc.Spec), nil
+}
+
+// Wait blocks until condition reports kvp as ready, ctx is cancelled, or timeout elapses,
+// whichever comes first, returning the KVPair as of the last observed update. It starts from a
+// Get so a resource that's already ready returns immediately, then falls back to Watch, retrying
+// the watch itself with a bounded backoff if the connection drops -- the same shape Helm's
+// kube client's resource-ready wait loop uses, adapted to this client's Watch rather than
+// client-go's informers.
+func (c *customK8sResourceClient) Wait(ctx context.Context, key model.Key, condition Condition, timeout time.Duration) (*model.KVPair, error) {
+	logContext := log.WithFields(log.Fields{"Key": key, "Resource": c.resource})
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	kvp, err := c.Get(ctx, key, "")
+	if err == nil {
+		if ready, err := condition(kvp); err != nil {
+			return nil, err
+		} else if ready {
+			return kvp, nil
+		}
+	}
+
+	resKey := key.(model.ResourceKey)
+	backoff := 100 * time.Millisecond
+	const maxBackoff = 5 * time.Second
+
+	for {
+		watcher, err := c.Watch(ctx, model.ResourceListOptions{Kind: resKey.Kind, Name: resKey.Name, Namespace: resKey.Namespace}, api.WatchOptions{})
+		if err != nil {
+			logContext.WithError(err).Debug("Error starting watch, retrying with backoff")
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(backoff):
+			}
+			if backoff *= 2; backoff > maxBackoff {
+				backoff = maxBackoff
+			}
+			continue
+		}
+
+		kvp, done, err := waitOnWatch(ctx, watcher, condition)
+		watcher.Stop()
+		if done {
+			return kvp, err
+		}
+		// The watch's channel closed without satisfying condition or hitting ctx -- the
+		// connection dropped. Back off and re-establish it.
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(backoff):
+		}
+		if backoff *= 2; backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+	}
+}
+
+// waitOnWatch drains watcher until condition is satisfied, ctx is done, or watcher's channel
+// closes. done is false only in the last case, telling Wait's caller to re-establish the watch.
+func waitOnWatch(ctx context.Context, watcher api.WatchInterface, condition Condition) (kvp *model.KVPair, done bool, err error) {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, true, ctx.Err()
+		case ev, ok := <-watcher.ResultChan():
+			if !ok {
+				return nil, false, nil
+			}
+			if ev.Type == api.WatchError {
+				return nil, true, ev.Error
+			}
+			if ev.New == nil || ev.New.Value == nil {
+				continue
+			}
+			ready, err := condition(ev.New)
+			if err != nil {
+				return nil, true, err
+			}
+			if ready {
+				return ev.New, true, nil
+			}
+		}
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"testing"
+	"time"
+
+	apiv3 "github.com/projectcalico/api/pkg/apis/projectcalico/v3"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/model"
+)
+
+// newConvergedSpec returns a fresh KubeControllersConfigurationSpec each time it's called, so a
+// test can build Spec and Status.RunningConfig as two independently-allocated values with the
+// same content -- the same shape two separately-unmarshaled API responses would take.
+func newConvergedSpec() apiv3.KubeControllersConfigurationSpec {
+	return apiv3.KubeControllersConfigurationSpec{
+		HealthChecks: "Enabled",
+		Controllers: apiv3.ControllersConfig{
+			Node: &apiv3.NodeControllerConfig{
+				ReconcilerPeriod: &metav1.Duration{Duration: 5 * time.Minute},
+			},
+		},
+	}
+}
+
+func TestKubeControllersConverged(t *testing.T) {
+	t.Run("converged", func(t *testing.T) {
+		// Spec and RunningConfig are built from two separate calls, so their Controllers.Node
+		// pointers differ even though the values they point to are identical -- exactly the
+		// case a plain == on the struct gets wrong.
+		kcc := &apiv3.KubeControllersConfiguration{
+			Spec: newConvergedSpec(),
+			Status: apiv3.KubeControllersConfigurationStatus{
+				RunningConfig: newConvergedSpec(),
+			},
+		}
+		ready, err := kubeControllersConverged(&model.KVPair{Value: kcc})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !ready {
+			t.Fatal("expected RunningConfig to be reported as converged with Spec")
+		}
+	})
+
+	t.Run("not converged", func(t *testing.T) {
+		running := newConvergedSpec()
+		running.Controllers.Node.ReconcilerPeriod = &metav1.Duration{Duration: time.Minute}
+		kcc := &apiv3.KubeControllersConfiguration{
+			Spec: newConvergedSpec(),
+			Status: apiv3.KubeControllersConfigurationStatus{
+				RunningConfig: running,
+			},
+		}
+		ready, err := kubeControllersConverged(&model.KVPair{Value: kcc})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if ready {
+			t.Fatal("expected RunningConfig to be reported as not converged with Spec")
+		}
+	})
+
+	t.Run("wrong type", func(t *testing.T) {
+		if _, err := kubeControllersConverged(&model.KVPair{Value: &apiv3.IPPool{}}); err == nil {
+			t.Fatal("expected an error for a KVPair value that isn't a KubeControllersConfiguration")
+		}
+	})
+}
@@ -0,0 +1,184 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	kwatch "k8s.io/apimachinery/pkg/watch"
+	kubevirtv1 "kubevirt.io/api/core/v1"
+
+	"github.com/projectcalico/calico/libcalico-go/lib/apis/internalapi"
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/api"
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/model"
+	cerrors "github.com/projectcalico/calico/libcalico-go/lib/errors"
+)
+
+// VMClient provides read access to VirtualMachine resources in a specific namespace. Kept
+// minimal and decoupled from kubevirt.io/client-go for the same reason as VMIClient.
+type VMClient interface {
+	Get(ctx context.Context, name string, opts metav1.GetOptions) (*kubevirtv1.VirtualMachine, error)
+	List(ctx context.Context, opts metav1.ListOptions) (*kubevirtv1.VirtualMachineList, error)
+	Watch(ctx context.Context, opts metav1.ListOptions) (kwatch.Interface, error)
+}
+
+// NewVirtualMachineClient returns a read-only K8sResourceClient that exposes KubeVirt
+// VirtualMachine resources under internalapi.KindKubeVirtVM. Unlike LiveMigrationClient, no
+// translation is needed beyond wrapping the resource in a KVPair: PolicyResolver's
+// KubeVirtVMWatcher (felix/calc) consumes the raw kubevirtv1.VirtualMachine value and derives
+// EndpointComputedData from it itself, rather than this client projecting a Calico-shaped type.
+func NewVirtualMachineClient(vmClient func(namespace string) VMClient) K8sResourceClient {
+	return &kubeVirtPassthroughClient{
+		kind: internalapi.KindKubeVirtVM,
+		get: func(ctx context.Context, namespace, name string, opts metav1.GetOptions) (Resource, error) {
+			return vmClient(namespace).Get(ctx, name, opts)
+		},
+		listFn: func(ctx context.Context, namespace string, opts metav1.ListOptions) (items []Resource, revision string, cont string, err error) {
+			l, err := vmClient(namespace).List(ctx, opts)
+			if err != nil {
+				return nil, "", "", err
+			}
+			for i := range l.Items {
+				items = append(items, &l.Items[i])
+			}
+			return items, l.ResourceVersion, l.Continue, nil
+		},
+		watch: func(ctx context.Context, namespace string, opts metav1.ListOptions) (kwatch.Interface, error) {
+			return vmClient(namespace).Watch(ctx, opts)
+		},
+	}
+}
+
+// NewVirtualMachineInstanceClient is NewVirtualMachineClient's VirtualMachineInstance
+// counterpart, exposed under internalapi.KindKubeVirtVMI.
+func NewVirtualMachineInstanceClient(vmiClient func(namespace string) VMIClient) K8sResourceClient {
+	return &kubeVirtPassthroughClient{
+		kind: internalapi.KindKubeVirtVMI,
+		get: func(ctx context.Context, namespace, name string, opts metav1.GetOptions) (Resource, error) {
+			return vmiClient(namespace).Get(ctx, name, opts)
+		},
+		listFn: func(ctx context.Context, namespace string, opts metav1.ListOptions) (items []Resource, revision string, cont string, err error) {
+			l, err := vmiClient(namespace).List(ctx, opts)
+			if err != nil {
+				return nil, "", "", err
+			}
+			for i := range l.Items {
+				items = append(items, &l.Items[i])
+			}
+			return items, l.ResourceVersion, l.Continue, nil
+		},
+		watch: func(ctx context.Context, namespace string, opts metav1.ListOptions) (kwatch.Interface, error) {
+			return vmiClient(namespace).Watch(ctx, opts)
+		},
+	}
+}
+
+// kubeVirtPassthroughClient is the shared K8sResourceClient implementation backing
+// NewVirtualMachineClient and NewVirtualMachineInstanceClient: both resources are consumed
+// read-only and need no Calico-specific conversion, so rather than duplicate List/Watch/Get
+// plumbing (as LiveMigrationClient needs, since it does convert), both share this one type
+// parameterized by kind and the three k8s-side accessors.
+type kubeVirtPassthroughClient struct {
+	kind   string
+	get    func(ctx context.Context, namespace, name string, opts metav1.GetOptions) (Resource, error)
+	listFn func(ctx context.Context, namespace string, opts metav1.ListOptions) (items []Resource, revision string, cont string, err error)
+	watch  func(ctx context.Context, namespace string, opts metav1.ListOptions) (kwatch.Interface, error)
+}
+
+func (c *kubeVirtPassthroughClient) Create(ctx context.Context, kvp *model.KVPair) (*model.KVPair, error) {
+	return nil, errOperationNotSupported(c.kind, kvp.Key, "Create")
+}
+
+func (c *kubeVirtPassthroughClient) Update(ctx context.Context, kvp *model.KVPair) (*model.KVPair, error) {
+	return nil, errOperationNotSupported(c.kind, kvp.Key, "Update")
+}
+
+func (c *kubeVirtPassthroughClient) DeleteKVP(ctx context.Context, kvp *model.KVPair) (*model.KVPair, error) {
+	return nil, errOperationNotSupported(c.kind, kvp.Key, "Delete")
+}
+
+func (c *kubeVirtPassthroughClient) Delete(ctx context.Context, key model.Key, revision string, uid *types.UID) (*model.KVPair, error) {
+	return nil, errOperationNotSupported(c.kind, key, "Delete")
+}
+
+func (c *kubeVirtPassthroughClient) Get(ctx context.Context, key model.Key, revision string) (*model.KVPair, error) {
+	k := key.(model.ResourceKey)
+	res, err := c.get(ctx, k.Namespace, k.Name, metav1.GetOptions{ResourceVersion: revision})
+	if err != nil {
+		return nil, K8sErrorToCalico(err, key)
+	}
+	return c.toKVPair(res), nil
+}
+
+func (c *kubeVirtPassthroughClient) List(ctx context.Context, list model.ListInterface, revision string) (*model.KVPairList, error) {
+	l := list.(model.ResourceListOptions)
+	opts := metav1.ListOptions{
+		ResourceVersion: revision,
+		LabelSelector:   l.LabelSelector,
+		FieldSelector:   l.FieldSelector,
+		Limit:           l.Limit,
+		Continue:        l.Continue,
+	}
+	items, revisionOut, cont, err := c.listFn(ctx, l.Namespace, opts)
+	if err != nil {
+		return nil, K8sErrorToCalico(err, list)
+	}
+	kvps := make([]*model.KVPair, 0, len(items))
+	for _, item := range items {
+		kvps = append(kvps, c.toKVPair(item))
+	}
+	return &model.KVPairList{KVPairs: kvps, Revision: revisionOut, Continue: cont}, nil
+}
+
+func (c *kubeVirtPassthroughClient) Watch(ctx context.Context, list model.ListInterface, options api.WatchOptions) (api.WatchInterface, error) {
+	rlo := list.(model.ResourceListOptions)
+	k8sOpts := watchOptionsToK8sListOptions(options)
+	k8sOpts.LabelSelector = rlo.LabelSelector
+	k8sOpts.FieldSelector = rlo.FieldSelector
+	k8sWatch, err := c.watch(ctx, rlo.Namespace, k8sOpts)
+	if err != nil {
+		return nil, K8sErrorToCalico(err, list)
+	}
+	return newK8sWatcherConverter(ctx, c.kind, func(r Resource) (*model.KVPair, error) {
+		return c.toKVPair(r), nil
+	}, k8sWatch), nil
+}
+
+func (c *kubeVirtPassthroughClient) EnsureInitialized() error {
+	return nil
+}
+
+func (c *kubeVirtPassthroughClient) toKVPair(res Resource) *model.KVPair {
+	om := res.GetObjectMeta()
+	return &model.KVPair{
+		Key: model.ResourceKey{
+			Kind:      c.kind,
+			Namespace: om.GetNamespace(),
+			Name:      om.GetName(),
+		},
+		Value:    res,
+		Revision: om.GetResourceVersion(),
+	}
+}
+
+func errOperationNotSupported(kind string, identifier model.Key, op string) error {
+	return cerrors.ErrorOperationNotSupported{
+		Identifier: identifier,
+		Operation:  op,
+		Reason:     kind + " is read-only in the Kubernetes backend",
+	}
+}
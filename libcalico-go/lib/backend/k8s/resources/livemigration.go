@@ -17,6 +17,8 @@ package resources
 import (
 	"context"
 	"fmt"
+	"strconv"
+	"sync"
 
 	log "github.com/sirupsen/logrus"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -40,8 +42,53 @@ type VMIMClient interface {
 	Watch(ctx context.Context, opts metav1.ListOptions) (kwatch.Interface, error)
 }
 
-func NewLiveMigrationClient(vmimClient func(namespace string) VMIMClient) K8sResourceClient {
-	return &LiveMigrationClient{vmimClient: vmimClient}
+// VMIClient provides read access to VirtualMachineInstance resources in a specific namespace.
+// Analogous to VMIMClient, this is a minimal interface so that this package doesn't need to
+// depend directly on kubevirt.io/client-go.
+type VMIClient interface {
+	Get(ctx context.Context, name string, opts metav1.GetOptions) (*kubevirtv1.VirtualMachineInstance, error)
+	List(ctx context.Context, opts metav1.ListOptions) (*kubevirtv1.VirtualMachineInstanceList, error)
+	Watch(ctx context.Context, opts metav1.ListOptions) (kwatch.Interface, error)
+}
+
+// VMIMWriter provides the subset of write access to VirtualMachineInstanceMigration
+// resources that the Calico controller needs in order to turn LiveMigration into a
+// control point rather than a purely read-only view. It is kept separate from
+// VMIMClient so that read-only callers (e.g. Felix) don't need to be granted RBAC
+// to mutate VMIMs.
+type VMIMWriter interface {
+	Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error
+}
+
+// LiveMigrationClientOption customizes a LiveMigrationClient constructed via NewLiveMigrationClient.
+type LiveMigrationClientOption func(*LiveMigrationClient)
+
+// WithVMIMWriter opts in to allowing Delete/DeleteKVP to abort an in-flight migration by deleting
+// the backing VMIM, e.g. when policy programming on the destination fails or preconditions can't
+// be met. Create and Update remain unsupported, since LiveMigration's spec is always derived from
+// the VMIM.
+func WithVMIMWriter(vmimWriter func(namespace string) VMIMWriter) LiveMigrationClientOption {
+	return func(c *LiveMigrationClient) {
+		c.vmimWriter = vmimWriter
+	}
+}
+
+// WithVMIClient opts in to cross-referencing the migration's VMI to enrich the emitted
+// LiveMigration with network-aware fields (secondary networks/interfaces, node selector/affinity),
+// for policy that needs to reason about those.
+func WithVMIClient(vmiClient func(namespace string) VMIClient) LiveMigrationClientOption {
+	return func(c *LiveMigrationClient) {
+		c.vmiClient = vmiClient
+		c.vmiCache = newVMICache()
+	}
+}
+
+func NewLiveMigrationClient(vmimClient func(namespace string) VMIMClient, opts ...LiveMigrationClientOption) K8sResourceClient {
+	c := &LiveMigrationClient{vmimClient: vmimClient}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
 // LiveMigrationClient implements the K8sResourceClient interface for LiveMigration
@@ -49,6 +96,15 @@ func NewLiveMigrationClient(vmimClient func(namespace string) VMIMClient) K8sRes
 // resources in the Kubernetes datastore.
 type LiveMigrationClient struct {
 	vmimClient func(namespace string) VMIMClient
+
+	// vmimWriter is nil unless this client was constructed with the WithVMIMWriter option, in
+	// which case Delete/DeleteKVP are allowed to abort the migration by deleting the VMIM.
+	vmimWriter func(namespace string) VMIMWriter
+
+	// vmiClient is nil unless this client was constructed with the WithVMIClient option, in
+	// which case we cross-reference the migration's VMI to enrich the emitted LiveMigration.
+	vmiClient func(namespace string) VMIClient
+	vmiCache  *vmiCache
 }
 
 func (c *LiveMigrationClient) Create(ctx context.Context, kvp *model.KVPair) (*model.KVPair, error) {
@@ -67,20 +123,39 @@ func (c *LiveMigrationClient) Update(ctx context.Context, kvp *model.KVPair) (*m
 	}
 }
 
+// Delete aborts an in-flight migration by deleting the backing VirtualMachineInstanceMigration,
+// if this client was constructed with a VMIMWriter. Otherwise it returns ErrorOperationNotSupported,
+// consistent with Create/Update.
 func (c *LiveMigrationClient) Delete(ctx context.Context, key model.Key, revision string, uid *types.UID) (*model.KVPair, error) {
-	return nil, cerrors.ErrorOperationNotSupported{
-		Identifier: key,
-		Operation:  "Delete",
-		Reason:     "LiveMigration is read-only in the Kubernetes backend",
+	if c.vmimWriter == nil {
+		return nil, cerrors.ErrorOperationNotSupported{
+			Identifier: key,
+			Operation:  "Delete",
+			Reason:     "LiveMigration is read-only in the Kubernetes backend",
+		}
 	}
+	existing, err := c.Get(ctx, key, revision)
+	if err != nil {
+		return nil, err
+	}
+
+	k := key.(model.ResourceKey)
+	var preconditions metav1.Preconditions
+	if uid != nil {
+		preconditions.UID = uid
+	}
+	if revision != "" {
+		preconditions.ResourceVersion = &revision
+	}
+	opts := metav1.DeleteOptions{Preconditions: &preconditions}
+	if err := c.vmimWriter(k.Namespace).Delete(ctx, k.Name, opts); err != nil {
+		return nil, K8sErrorToCalico(err, key)
+	}
+	return existing, nil
 }
 
 func (c *LiveMigrationClient) DeleteKVP(ctx context.Context, kvp *model.KVPair) (*model.KVPair, error) {
-	return nil, cerrors.ErrorOperationNotSupported{
-		Identifier: kvp.Key,
-		Operation:  "DeleteKVP",
-		Reason:     "LiveMigration is read-only in the Kubernetes backend",
-	}
+	return c.Delete(ctx, kvp.Key, kvp.Revision, kvp.UID)
 }
 
 func (c *LiveMigrationClient) Get(ctx context.Context, key model.Key, revision string) (*model.KVPair, error) {
@@ -92,7 +167,7 @@ func (c *LiveMigrationClient) Get(ctx context.Context, key model.Key, revision s
 	if !vmimShouldEmitLiveMigration(vmim) {
 		return nil, cerrors.ErrorResourceDoesNotExist{Identifier: key}
 	}
-	return convertVMIMToLiveMigration(vmim), nil
+	return c.convertVMIMToLiveMigration(ctx, vmim), nil
 }
 
 func (c *LiveMigrationClient) List(ctx context.Context, list model.ListInterface, revision string) (*model.KVPairList, error) {
@@ -100,7 +175,18 @@ func (c *LiveMigrationClient) List(ctx context.Context, list model.ListInterface
 	logContext.Debug("Received List request")
 	l := list.(model.ResourceListOptions)
 
-	opts := metav1.ListOptions{ResourceVersion: revision}
+	// LabelSelector/FieldSelector/Limit/Continue are forwarded straight through to the VMIM
+	// List call, so a VMIMClient that honours them filters and paginates against the VMIM store
+	// rather than after we've materialized every VMIM into a LiveMigration. The informer-backed
+	// client kubevirt.Enable installs (NewInformerBackedVMIMClient) ignores them and returns
+	// every cached VMIM in the namespace.
+	opts := metav1.ListOptions{
+		ResourceVersion: revision,
+		LabelSelector:   l.LabelSelector,
+		FieldSelector:   l.FieldSelector,
+		Limit:           l.Limit,
+		Continue:        l.Continue,
+	}
 	if revision != "" {
 		opts.ResourceVersionMatch = metav1.ResourceVersionMatchNotOlderThan
 	}
@@ -115,54 +201,183 @@ func (c *LiveMigrationClient) List(ctx context.Context, list model.ListInterface
 		if !vmimShouldEmitLiveMigration(&result.Items[i]) {
 			continue
 		}
-		kvps = append(kvps, convertVMIMToLiveMigration(&result.Items[i]))
+		kvps = append(kvps, c.convertVMIMToLiveMigration(ctx, &result.Items[i]))
 	}
 
 	return &model.KVPairList{
 		KVPairs:  kvps,
 		Revision: result.ResourceVersion,
+		Continue: result.Continue,
 	}, nil
 }
 
 func (c *LiveMigrationClient) Watch(ctx context.Context, list model.ListInterface, options api.WatchOptions) (api.WatchInterface, error) {
 	rlo := list.(model.ResourceListOptions)
 	k8sOpts := watchOptionsToK8sListOptions(options)
-	k8sWatch, err := c.vmimClient(rlo.Namespace).Watch(ctx, k8sOpts)
+
+	// Pass LabelSelector/FieldSelector to the VMIM watch the same way List does, so a VMIMClient
+	// that honours them avoids streaming events for VMIMs the caller isn't watching (the
+	// informer-backed client ignores them and streams every VMIM in the namespace).
+	// This selector describes VMIMs, not VMIs, so it's applied to vmimOpts only; the VMI
+	// enrichment watch below stays unfiltered.
+	vmimOpts := k8sOpts
+	vmimOpts.LabelSelector = rlo.LabelSelector
+	vmimOpts.FieldSelector = rlo.FieldSelector
+	k8sWatch, err := c.vmimClient(rlo.Namespace).Watch(ctx, vmimOpts)
 	if err != nil {
 		return nil, K8sErrorToCalico(err, list)
 	}
-	return newK8sWatcherConverter(ctx, "VirtualMachineInstanceMigration", convertVMIMResourceToLiveMigration, k8sWatch), nil
+	if c.vmiClient != nil {
+		// Also watch VMIs, so that a change to the VM's networks/interfaces while a migration
+		// is in flight causes us to re-emit the LiveMigration it's cross-referenced into.
+		vmiWatch, err := c.vmiClient(rlo.Namespace).Watch(ctx, k8sOpts)
+		if err != nil {
+			log.WithError(err).Warn("Failed to watch VirtualMachineInstances for LiveMigration enrichment; " +
+				"continuing with VMIM-only watch")
+		} else {
+			k8sWatch = newVMIMAndVMIMergedWatch(ctx, c.vmimClient(rlo.Namespace), k8sWatch, vmiWatch, c.vmiCache)
+		}
+	}
+	return newK8sWatcherConverter(ctx, "VirtualMachineInstanceMigration", c.convertVMIMResourceToLiveMigration(ctx), k8sWatch), nil
 }
 
 func (c *LiveMigrationClient) EnsureInitialized() error {
 	return nil
 }
 
+// vmimEarlyPhases are phases before KubeVirt has necessarily populated MigrationState, where we
+// still want to let Calico pre-program policy while the target pod is being scheduled.
+var vmimEarlyPhases = map[kubevirtv1.VirtualMachineInstanceMigrationPhase]bool{
+	kubevirtv1.MigrationPending:         true,
+	kubevirtv1.MigrationScheduling:      true,
+	kubevirtv1.MigrationScheduled:       true,
+	kubevirtv1.MigrationPreparingTarget: true,
+}
+
 // vmimShouldEmitLiveMigration returns true if the VMIM is in a phase that warrants emitting a
-// LiveMigration resource and has the required fields set.  In more detail: only if the migration is
-// actively preparing, running, or failing, and we have the VM Name, Source Pod, and Object UID
-// established.
+// LiveMigration resource and has the required fields set. We emit from MigrationPending onwards, so
+// that Calico can pre-program policy while the target pod is still being scheduled, through
+// MigrationRunning/MigrationFailed, and on to MigrationSucceeded so that downstream consumers get a
+// chance to reconcile/tear down before the VMIM is garbage collected.
 func vmimShouldEmitLiveMigration(vmim *kubevirtv1.VirtualMachineInstanceMigration) bool {
 	switch vmim.Status.Phase {
-	case kubevirtv1.MigrationTargetReady, kubevirtv1.MigrationRunning, kubevirtv1.MigrationFailed:
+	case kubevirtv1.MigrationTargetReady, kubevirtv1.MigrationRunning, kubevirtv1.MigrationFailed, kubevirtv1.MigrationSucceeded:
 	default:
-		return false
+		if !vmimEarlyPhases[vmim.Status.Phase] {
+			return false
+		}
 	}
 	if vmim.Spec.VMIName == "" {
 		return false
 	}
-	if vmim.Status.MigrationState == nil || vmim.Status.MigrationState.SourcePod == "" {
+	if vmim.UID == "" {
 		return false
 	}
-	if vmim.UID == "" {
+	if vmimEarlyPhases[vmim.Status.Phase] {
+		// SourcePod isn't established yet this early; everything else about the migration
+		// (selector-based destination, phase) is still useful to emit.
+		return true
+	}
+	if vmim.Status.MigrationState == nil || vmim.Status.MigrationState.SourcePod == "" {
 		return false
 	}
 	return true
 }
 
+// vmiCache memoizes VirtualMachineInstance lookups keyed by namespace/name, so that repeated
+// LiveMigration conversions (e.g. while a migration sits in a given phase) don't hot-loop fetching
+// the same VMI from the API server. Entries are replaced whenever a fresher ResourceVersion is
+// seen, whether from a fresh Get (getVMI) or from the VMI watch newVMIMAndVMIMergedWatch merges
+// in, and dropped outright once the VMI is deleted.
+type vmiCache struct {
+	mu      sync.Mutex
+	entries map[types.NamespacedName]*kubevirtv1.VirtualMachineInstance
+}
+
+func newVMICache() *vmiCache {
+	return &vmiCache{entries: map[types.NamespacedName]*kubevirtv1.VirtualMachineInstance{}}
+}
+
+func (c *vmiCache) get(key types.NamespacedName) (*kubevirtv1.VirtualMachineInstance, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	vmi, ok := c.entries[key]
+	return vmi, ok
+}
+
+func (c *vmiCache) set(key types.NamespacedName, vmi *kubevirtv1.VirtualMachineInstance) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if existing, ok := c.entries[key]; ok && !isFresherResourceVersion(vmi.ResourceVersion, existing.ResourceVersion) {
+		// Don't let a stale event (e.g. a requeued watch event, or the initial Get racing a
+		// concurrent watch update) clobber an entry set() has already refreshed.
+		return
+	}
+	c.entries[key] = vmi
+}
+
+// forget drops key's cached VMI, for a VMI that's been deleted -- there's nothing fresher to
+// compare against at that point, so the entry must go rather than linger until something else
+// happens to overwrite it.
+func (c *vmiCache) forget(key types.NamespacedName) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, key)
+}
+
+// isFresherResourceVersion reports whether candidate is at least as new as current. Kubernetes
+// resource versions are opaque strings, but for the API server backends this client targets
+// they're etcd mod-revisions, which are monotonically increasing decimal integers; when either
+// side fails to parse that way, there's no meaningful ordering to apply, so the candidate (always
+// the one just read from a Get or observed on the watch) wins rather than risk ignoring genuinely
+// newer data.
+func isFresherResourceVersion(candidate, current string) bool {
+	c, errC := strconv.ParseUint(candidate, 10, 64)
+	cur, errCur := strconv.ParseUint(current, 10, 64)
+	if errC != nil || errCur != nil {
+		return true
+	}
+	return c >= cur
+}
+
+// getVMI looks up the VMI referenced by vmim.Spec.VMIName, preferring the cache and only hitting
+// the API server when we don't already have an entry for it. Returns nil if the VMI can't be
+// fetched (whatever the error), so that callers can fall back to selector-only behavior.
+func (c *LiveMigrationClient) getVMI(ctx context.Context, vmim *kubevirtv1.VirtualMachineInstanceMigration) *kubevirtv1.VirtualMachineInstance {
+	if c.vmiClient == nil || vmim.Spec.VMIName == "" {
+		return nil
+	}
+	key := types.NamespacedName{Namespace: vmim.Namespace, Name: vmim.Spec.VMIName}
+	if vmi, ok := c.vmiCache.get(key); ok {
+		return vmi
+	}
+	vmi, err := c.vmiClient(vmim.Namespace).Get(ctx, vmim.Spec.VMIName, metav1.GetOptions{})
+	if err != nil {
+		log.WithError(err).WithField("vmi", key).Debug(
+			"Failed to look up VMI for LiveMigration enrichment; falling back to selector-only destination")
+		return nil
+	}
+	c.vmiCache.set(key, vmi)
+	return vmi
+}
+
+// applyVMINetworkInfo copies a curated subset of the VMI spec onto the LiveMigration so that
+// policy can reason about which secondary networks/interfaces and node placement constraints the
+// migrating VM uses.
+func applyVMINetworkInfo(lm *internalapi.LiveMigration, vmi *kubevirtv1.VirtualMachineInstance) {
+	if vmi == nil {
+		return
+	}
+	lm.Spec.Networks = vmi.Spec.Networks
+	lm.Spec.Interfaces = vmi.Spec.Domain.Devices.Interfaces
+	lm.Spec.NodeSelector = vmi.Spec.NodeSelector
+	lm.Spec.Affinity = vmi.Spec.Affinity
+}
+
 // convertVMIMToLiveMigration converts a KubeVirt VirtualMachineInstanceMigration
-// to a Calico LiveMigration KVPair.
-func convertVMIMToLiveMigration(vmim *kubevirtv1.VirtualMachineInstanceMigration) *model.KVPair {
+// to a Calico LiveMigration KVPair, cross-referencing the VMI when this client was
+// constructed with a VMIClient.
+func (c *LiveMigrationClient) convertVMIMToLiveMigration(ctx context.Context, vmim *kubevirtv1.VirtualMachineInstanceMigration) *model.KVPair {
 	var lm *internalapi.LiveMigration
 	if vmimShouldEmitLiveMigration(vmim) {
 		lm = internalapi.NewLiveMigration()
@@ -180,15 +395,34 @@ func convertVMIMToLiveMigration(vmim *kubevirtv1.VirtualMachineInstanceMigration
 			kubevirtv1.MigrationJobLabel,
 			string(vmim.UID),
 		)
+		destination := &internalapi.WorkloadEndpointIdentifier{
+			Selector: &selector,
+		}
+		var source *types.NamespacedName
+		if vmim.Status.MigrationState != nil {
+			if vmim.Status.MigrationState.TargetPod != "" {
+				// Once KubeVirt has created the target virt-launcher pod, we can name it
+				// directly instead of making downstream consumers resolve the selector
+				// themselves. Keep the selector populated too, so that consumers which
+				// haven't caught up with the watch update yet still have something to match on.
+				destination.NamespacedName = &types.NamespacedName{
+					Name:      vmim.Status.MigrationState.TargetPod,
+					Namespace: vmim.Namespace,
+				}
+			}
+			if vmim.Status.MigrationState.SourcePod != "" {
+				source = &types.NamespacedName{
+					Name:      vmim.Status.MigrationState.SourcePod,
+					Namespace: vmim.Namespace,
+				}
+			}
+		}
 		lm.Spec = internalapi.LiveMigrationSpec{
-			Source: &types.NamespacedName{
-				Name:      vmim.Status.MigrationState.SourcePod,
-				Namespace: vmim.Namespace,
-			},
-			Destination: &internalapi.WorkloadEndpointIdentifier{
-				Selector: &selector,
-			},
+			Phase:       string(vmim.Status.Phase),
+			Source:      source,
+			Destination: destination,
 		}
+		applyVMINetworkInfo(lm, c.getVMI(ctx, vmim))
 	}
 	return &model.KVPair{
 		Key: model.ResourceKey{
@@ -201,8 +435,77 @@ func convertVMIMToLiveMigration(vmim *kubevirtv1.VirtualMachineInstanceMigration
 	}
 }
 
-// convertVMIMResourceToLiveMigration is a ConvertK8sResourceToKVPair adapter
-// for the watch converter.
-func convertVMIMResourceToLiveMigration(r Resource) (*model.KVPair, error) {
-	return convertVMIMToLiveMigration(r.(*kubevirtv1.VirtualMachineInstanceMigration)), nil
+// convertVMIMResourceToLiveMigration returns a ConvertK8sResourceToKVPair adapter for the watch
+// converter, bound to this client so that VMI cross-referencing (if configured) is applied.
+func (c *LiveMigrationClient) convertVMIMResourceToLiveMigration(ctx context.Context) func(Resource) (*model.KVPair, error) {
+	return func(r Resource) (*model.KVPair, error) {
+		return c.convertVMIMToLiveMigration(ctx, r.(*kubevirtv1.VirtualMachineInstanceMigration)), nil
+	}
+}
+
+// newVMIMAndVMIMergedWatch wraps a VMIM watch and a VMI watch into a single kwatch.Interface of
+// VMIM events. Every VMI event first refreshes vmiCache (so applyVMINetworkInfo stops serving
+// whatever getVMI cached on the first lookup), then is translated into a synthetic MODIFIED event
+// for every VMIM in the same namespace whose Spec.VMIName references the changed VMI, so that the
+// refreshed enrichment gets re-emitted even though the VMIM itself didn't change.
+func newVMIMAndVMIMergedWatch(ctx context.Context, vmimClient VMIMClient, vmimWatch, vmiWatch kwatch.Interface, vmiCache *vmiCache) kwatch.Interface {
+	out := make(chan kwatch.Event)
+	w := &vmimAndVMIMergedWatch{vmimWatch: vmimWatch, vmiWatch: vmiWatch, vmiCache: vmiCache, out: out}
+	go w.run(ctx, vmimClient)
+	return w
+}
+
+type vmimAndVMIMergedWatch struct {
+	vmimWatch kwatch.Interface
+	vmiWatch  kwatch.Interface
+	vmiCache  *vmiCache
+	out       chan kwatch.Event
+}
+
+func (w *vmimAndVMIMergedWatch) ResultChan() <-chan kwatch.Event {
+	return w.out
+}
+
+func (w *vmimAndVMIMergedWatch) Stop() {
+	w.vmimWatch.Stop()
+	w.vmiWatch.Stop()
+}
+
+func (w *vmimAndVMIMergedWatch) run(ctx context.Context, vmimClient VMIMClient) {
+	defer close(w.out)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case ev, ok := <-w.vmimWatch.ResultChan():
+			if !ok {
+				return
+			}
+			w.out <- ev
+		case ev, ok := <-w.vmiWatch.ResultChan():
+			if !ok {
+				return
+			}
+			vmi, ok := ev.Object.(*kubevirtv1.VirtualMachineInstance)
+			if !ok {
+				continue
+			}
+			if ev.Type == kwatch.Deleted {
+				w.vmiCache.forget(types.NamespacedName{Namespace: vmi.Namespace, Name: vmi.Name})
+			} else {
+				w.vmiCache.set(types.NamespacedName{Namespace: vmi.Namespace, Name: vmi.Name}, vmi)
+			}
+			result, err := vmimClient.List(ctx, metav1.ListOptions{})
+			if err != nil {
+				log.WithError(err).Debug("Failed to list VMIMs while handling VMI change; will retry on next event")
+				continue
+			}
+			for i := range result.Items {
+				if result.Items[i].Namespace != vmi.Namespace || result.Items[i].Spec.VMIName != vmi.Name {
+					continue
+				}
+				w.out <- kwatch.Event{Type: kwatch.Modified, Object: &result.Items[i]}
+			}
+		}
+	}
 }
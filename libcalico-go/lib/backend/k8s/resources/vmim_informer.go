@@ -0,0 +1,175 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kwatch "k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+	kubevirtv1 "kubevirt.io/api/core/v1"
+)
+
+// NewVMIMSharedInformer builds a cache.SharedIndexInformer over VirtualMachineInstanceMigrations
+// across all namespaces, backed by rawClient. Build exactly one of these per process (e.g. in
+// whichever of Felix/confd/kube-controllers initializes first) and pass it to every subsequent
+// kubevirt.EnableWithVMIMInformer call in that process, so a syncer restart reads the informer's
+// already-populated local cache instead of re-issuing a List against the APIserver, and so
+// multiple components sharing a process don't each pay for their own List+Watch.
+func NewVMIMSharedInformer(rawClient func(namespace string) VMIMClient, resyncPeriod time.Duration) cache.SharedIndexInformer {
+	lw := &cache.ListWatch{
+		ListFunc: func(opts metav1.ListOptions) (runtime.Object, error) {
+			return rawClient(metav1.NamespaceAll).List(context.Background(), opts)
+		},
+		WatchFunc: func(opts metav1.ListOptions) (kwatch.Interface, error) {
+			return rawClient(metav1.NamespaceAll).Watch(context.Background(), opts)
+		},
+	}
+	return cache.NewSharedIndexInformer(
+		lw,
+		&kubevirtv1.VirtualMachineInstanceMigration{},
+		resyncPeriod,
+		cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc},
+	)
+}
+
+// NewInformerBackedVMIMClient returns a VMIMClient scoped to namespace (or metav1.NamespaceAll)
+// whose Get/List/Watch read from informer's local cache/delta FIFO rather than hitting the
+// APIserver. informer must already have been started (informer.Run) by the caller; this client
+// doesn't start or stop it, since it's expected to be shared across several such clients.
+// opts are not applied: List and Watch return every cached VMIM in the namespace whatever
+// LabelSelector, FieldSelector, Limit or Continue say, and Get ignores ResourceVersion.
+func NewInformerBackedVMIMClient(informer cache.SharedIndexInformer, namespace string) VMIMClient {
+	return &informerBackedVMIMClient{namespace: namespace, informer: informer}
+}
+
+type informerBackedVMIMClient struct {
+	namespace string
+	informer  cache.SharedIndexInformer
+}
+
+func (c *informerBackedVMIMClient) Get(ctx context.Context, name string, opts metav1.GetOptions) (*kubevirtv1.VirtualMachineInstanceMigration, error) {
+	key := name
+	if c.namespace != "" && c.namespace != metav1.NamespaceAll {
+		key = c.namespace + "/" + name
+	}
+	obj, exists, err := c.informer.GetIndexer().GetByKey(key)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, apierrors.NewNotFound(kubevirtv1.Resource("virtualmachineinstancemigrations"), name)
+	}
+	return obj.(*kubevirtv1.VirtualMachineInstanceMigration), nil
+}
+
+func (c *informerBackedVMIMClient) List(ctx context.Context, opts metav1.ListOptions) (*kubevirtv1.VirtualMachineInstanceMigrationList, error) {
+	var objs []interface{}
+	var err error
+	if c.namespace == "" || c.namespace == metav1.NamespaceAll {
+		objs = c.informer.GetStore().List()
+	} else {
+		objs, err = c.informer.GetIndexer().ByIndex(cache.NamespaceIndex, c.namespace)
+		if err != nil {
+			return nil, err
+		}
+	}
+	list := &kubevirtv1.VirtualMachineInstanceMigrationList{}
+	for _, obj := range objs {
+		list.Items = append(list.Items, *obj.(*kubevirtv1.VirtualMachineInstanceMigration))
+	}
+	return list, nil
+}
+
+// Watch replays the informer's current cache as a burst of synthetic Added events -- the
+// resync-on-restart semantics this chunk calls for, standing in for the List a fresh watch would
+// otherwise have to issue -- then streams subsequent Add/Update/Delete deltas from the informer's
+// event handlers until ctx is cancelled or Stop is called.
+func (c *informerBackedVMIMClient) Watch(ctx context.Context, opts metav1.ListOptions) (kwatch.Interface, error) {
+	return newInformerWatch(ctx, c.informer, c.namespace)
+}
+
+func newInformerWatch(ctx context.Context, informer cache.SharedIndexInformer, namespace string) (kwatch.Interface, error) {
+	w := &informerWatch{out: make(chan kwatch.Event), done: make(chan struct{})}
+
+	reg, err := informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(obj interface{}) { w.send(namespace, kwatch.Added, obj) },
+		UpdateFunc: func(_, obj interface{}) { w.send(namespace, kwatch.Modified, obj) },
+		DeleteFunc: func(obj interface{}) {
+			if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			w.send(namespace, kwatch.Deleted, obj)
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to subscribe to VMIM informer: %w", err)
+	}
+	w.reg = reg
+	w.informer = informer
+
+	// The replay needs no loop here: AddEventHandler itself queues an Add for every object
+	// already in the informer's cache, ahead of any later delta, so a watcher that starts after
+	// the informer has synced still sees the full current state. Those Adds are sent from the
+	// informer's own handler goroutine, so Watch returns before anything reads ResultChan.
+	go func() {
+		select {
+		case <-ctx.Done():
+			w.Stop()
+		case <-w.done:
+		}
+	}()
+	return w, nil
+}
+
+type informerWatch struct {
+	informer cache.SharedIndexInformer
+	reg      cache.ResourceEventHandlerRegistration
+	out      chan kwatch.Event
+	done     chan struct{}
+	stopped  bool
+}
+
+func (w *informerWatch) send(namespace string, t kwatch.EventType, obj interface{}) {
+	vmim, ok := obj.(*kubevirtv1.VirtualMachineInstanceMigration)
+	if !ok {
+		return
+	}
+	if namespace != "" && namespace != metav1.NamespaceAll && vmim.Namespace != namespace {
+		return
+	}
+	select {
+	case w.out <- kwatch.Event{Type: t, Object: vmim}:
+	case <-w.done:
+	}
+}
+
+func (w *informerWatch) ResultChan() <-chan kwatch.Event {
+	return w.out
+}
+
+func (w *informerWatch) Stop() {
+	if w.stopped {
+		return
+	}
+	w.stopped = true
+	close(w.done)
+	_ = w.informer.RemoveEventHandler(w.reg)
+}
@@ -0,0 +1,127 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	kwatch "k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+	kubevirtv1 "kubevirt.io/api/core/v1"
+)
+
+// fakeVMIMClient is an in-memory VMIMClient that counts outbound List calls, so tests can assert
+// the informer-backed client stops issuing them after the initial sync.
+type fakeVMIMClient struct {
+	items      []kubevirtv1.VirtualMachineInstanceMigration
+	listCalls  int32
+	watcher    *kwatch.FakeWatcher
+	watchCalls int32
+}
+
+func newFakeVMIMClient(n int) *fakeVMIMClient {
+	c := &fakeVMIMClient{watcher: kwatch.NewFake()}
+	for i := 0; i < n; i++ {
+		c.items = append(c.items, kubevirtv1.VirtualMachineInstanceMigration{
+			ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("vmim-%d", i), Namespace: "default", UID: types.UID(fmt.Sprintf("uid-%d", i))},
+		})
+	}
+	return c
+}
+
+func (c *fakeVMIMClient) Get(ctx context.Context, name string, opts metav1.GetOptions) (*kubevirtv1.VirtualMachineInstanceMigration, error) {
+	for i := range c.items {
+		if c.items[i].Name == name {
+			return &c.items[i], nil
+		}
+	}
+	return nil, fmt.Errorf("not found: %s", name)
+}
+
+func (c *fakeVMIMClient) List(ctx context.Context, opts metav1.ListOptions) (*kubevirtv1.VirtualMachineInstanceMigrationList, error) {
+	atomic.AddInt32(&c.listCalls, 1)
+	return &kubevirtv1.VirtualMachineInstanceMigrationList{Items: c.items}, nil
+}
+
+func (c *fakeVMIMClient) Watch(ctx context.Context, opts metav1.ListOptions) (kwatch.Interface, error) {
+	atomic.AddInt32(&c.watchCalls, 1)
+	return c.watcher, nil
+}
+
+// TestInformerBackedVMIMClient_RestartIsRelistFree spins up a fake backend with 1k synthetic
+// VMIMs, syncs the shared informer once, then simulates many syncer "restarts" -- repeated
+// List calls against fresh informer-backed clients, as a fresh syncer would issue on each
+// reconnect -- and asserts the outbound call count against the fake backend never grows past the
+// single initial List+Watch the informer itself made.
+func TestInformerBackedVMIMClient_RestartIsRelistFree(t *testing.T) {
+	const n = 1000
+	fake := newFakeVMIMClient(n)
+	informer := NewVMIMSharedInformer(func(string) VMIMClient { return fake }, time.Hour)
+
+	stop := make(chan struct{})
+	defer close(stop)
+	go informer.Run(stop)
+	if !cache.WaitForCacheSync(stop, informer.HasSynced) {
+		t.Fatal("informer failed to sync")
+	}
+
+	for i := 0; i < 10; i++ {
+		client := NewInformerBackedVMIMClient(informer, "default")
+		list, err := client.List(context.Background(), metav1.ListOptions{})
+		if err != nil {
+			t.Fatalf("List: %v", err)
+		}
+		if len(list.Items) != n {
+			t.Fatalf("restart %d: expected %d items, got %d", i, n, len(list.Items))
+		}
+	}
+
+	if calls := atomic.LoadInt32(&fake.listCalls); calls != 1 {
+		t.Errorf("expected exactly 1 outbound List call across all restarts, got %d", calls)
+	}
+	if calls := atomic.LoadInt32(&fake.watchCalls); calls != 1 {
+		t.Errorf("expected exactly 1 outbound Watch call across all restarts, got %d", calls)
+	}
+}
+
+// BenchmarkInformerBackedVMIMClient_Restart measures the cost of a syncer restart against an
+// informer-backed VMIMClient: each iteration re-lists the full 1k-item cache, which should cost
+// O(cache-size) local memory copies rather than a network round trip.
+func BenchmarkInformerBackedVMIMClient_Restart(b *testing.B) {
+	const n = 1000
+	fake := newFakeVMIMClient(n)
+	informer := NewVMIMSharedInformer(func(string) VMIMClient { return fake }, time.Hour)
+
+	stop := make(chan struct{})
+	defer close(stop)
+	go informer.Run(stop)
+	if !cache.WaitForCacheSync(stop, informer.HasSynced) {
+		b.Fatal("informer failed to sync")
+	}
+
+	client := NewInformerBackedVMIMClient(informer, "default")
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := client.List(context.Background(), metav1.ListOptions{}); err != nil {
+			b.Fatalf("List: %v", err)
+		}
+	}
+}
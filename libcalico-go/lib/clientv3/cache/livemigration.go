@@ -0,0 +1,143 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/projectcalico/calico/libcalico-go/lib/apis/internalapi"
+	"github.com/projectcalico/calico/libcalico-go/lib/clientv3"
+	"github.com/projectcalico/calico/libcalico-go/lib/options"
+	"github.com/projectcalico/calico/libcalico-go/lib/watch"
+)
+
+// CachedLiveMigrations decorates a LiveMigrationInterface with a read-through TTL cache: Get is
+// cached per (namespace, name), List is cached per set of list options, and both refresh
+// lazily — a stale entry is returned immediately while a single background call (shared across
+// any concurrent callers for the same key) fetches the new value. Create/Update/Delete
+// invalidate the affected Get entry synchronously, and drop the whole List cache, since a write
+// can change the result of any selector-based List.
+type CachedLiveMigrations struct {
+	inner clientv3.LiveMigrationInterface
+	opts  CacheOptions
+
+	gets  *keyedCache[*internalapi.LiveMigration]
+	lists *keyedCache[*internalapi.LiveMigrationList]
+}
+
+// NewCached wraps inner with a read-through cache governed by opts, returning a drop-in
+// LiveMigrationInterface.
+func NewCached(inner clientv3.LiveMigrationInterface, opts CacheOptions) clientv3.LiveMigrationInterface {
+	return &CachedLiveMigrations{
+		inner: inner,
+		opts:  opts,
+		gets:  newKeyedCache[*internalapi.LiveMigration](opts.TTL, opts.MaxEntries),
+		lists: newKeyedCache[*internalapi.LiveMigrationList](opts.TTL, opts.MaxEntries),
+	}
+}
+
+func getKey(namespace, name string) string {
+	return namespace + "/" + name
+}
+
+func listKey(opts options.ListOptions) string {
+	return fmt.Sprintf("%+v", opts)
+}
+
+func (c *CachedLiveMigrations) Get(ctx context.Context, namespace, name string, opts options.GetOptions) (*internalapi.LiveMigration, error) {
+	key := getKey(namespace, name)
+	fetch := func() (*internalapi.LiveMigration, error) {
+		return c.inner.Get(ctx, namespace, name, opts)
+	}
+
+	value, hit, needsRefresh := c.gets.get(key)
+	if !hit {
+		c.opts.miss(key)
+		value, err := c.gets.refresh(key, fetch)
+		c.opts.refreshed(key, err)
+		return value, err
+	}
+
+	c.opts.hit(key)
+	if needsRefresh {
+		c.gets.refreshAsync(key, func() (*internalapi.LiveMigration, error) {
+			v, err := fetch()
+			c.opts.refreshed(key, err)
+			return v, err
+		})
+	}
+	return value, nil
+}
+
+func (c *CachedLiveMigrations) List(ctx context.Context, opts options.ListOptions) (*internalapi.LiveMigrationList, error) {
+	key := listKey(opts)
+	fetch := func() (*internalapi.LiveMigrationList, error) {
+		return c.inner.List(ctx, opts)
+	}
+
+	value, hit, needsRefresh := c.lists.get(key)
+	if !hit {
+		c.opts.miss(key)
+		value, err := c.lists.refresh(key, fetch)
+		c.opts.refreshed(key, err)
+		return value, err
+	}
+
+	c.opts.hit(key)
+	if needsRefresh {
+		c.lists.refreshAsync(key, func() (*internalapi.LiveMigrationList, error) {
+			v, err := fetch()
+			c.opts.refreshed(key, err)
+			return v, err
+		})
+	}
+	return value, nil
+}
+
+func (c *CachedLiveMigrations) Create(ctx context.Context, res *internalapi.LiveMigration, opts options.SetOptions) (*internalapi.LiveMigration, error) {
+	out, err := c.inner.Create(ctx, res, opts)
+	c.invalidateWrite(res.Namespace, res.Name)
+	return out, err
+}
+
+func (c *CachedLiveMigrations) Update(ctx context.Context, res *internalapi.LiveMigration, opts options.SetOptions) (*internalapi.LiveMigration, error) {
+	out, err := c.inner.Update(ctx, res, opts)
+	c.invalidateWrite(res.Namespace, res.Name)
+	return out, err
+}
+
+func (c *CachedLiveMigrations) UpdateStatus(ctx context.Context, res *internalapi.LiveMigration, opts options.SetOptions) (*internalapi.LiveMigration, error) {
+	out, err := c.inner.UpdateStatus(ctx, res, opts)
+	c.invalidateWrite(res.Namespace, res.Name)
+	return out, err
+}
+
+func (c *CachedLiveMigrations) Delete(ctx context.Context, namespace, name string, opts options.DeleteOptions) (*internalapi.LiveMigration, error) {
+	out, err := c.inner.Delete(ctx, namespace, name, opts)
+	c.invalidateWrite(namespace, name)
+	return out, err
+}
+
+func (c *CachedLiveMigrations) Watch(ctx context.Context, opts options.ListOptions) (watch.Interface, error) {
+	return c.inner.Watch(ctx, opts)
+}
+
+// invalidateWrite drops the Get cache entry for (namespace, name) and the entire List cache,
+// since a write can change membership of any selector-based List result.
+func (c *CachedLiveMigrations) invalidateWrite(namespace, name string) {
+	c.gets.invalidate(getKey(namespace, name))
+	c.lists.invalidateAll()
+}
@@ -0,0 +1,60 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package cache provides a read-through, TTL-based caching decorator for clientv3 resource
+// interfaces, for hot read paths (e.g. Felix policy evaluation) that would otherwise round-trip
+// to the datastore on every Get/List.
+package cache
+
+import "time"
+
+// CacheOptions configures a caching decorator such as NewCached.
+type CacheOptions struct {
+	// TTL is how long a cached entry is served before it's considered stale. A stale entry is
+	// still returned immediately (stale-while-refresh); a refresh is kicked off in the
+	// background and subsequent calls see the refreshed value once it lands.
+	TTL time.Duration
+
+	// MaxEntries bounds the number of distinct Get keys (and, separately, List keys) held in
+	// the cache. Once reached, the least-recently-refreshed entry is evicted to make room for
+	// a new one. Zero means unbounded.
+	MaxEntries int
+
+	// OnHit, OnMiss and OnRefresh, if set, let callers wire up Prometheus counters without this
+	// package taking a dependency on any particular metrics library. OnHit and OnMiss are called
+	// synchronously from Get/List; OnRefresh is called when a refresh finishes, synchronously for
+	// a miss and from the background refresh goroutine for a stale entry. key identifies the
+	// cache entry (e.g. "namespace/name" for Get).
+	OnHit     func(key string)
+	OnMiss    func(key string)
+	OnRefresh func(key string, err error)
+}
+
+func (o CacheOptions) hit(key string) {
+	if o.OnHit != nil {
+		o.OnHit(key)
+	}
+}
+
+func (o CacheOptions) miss(key string) {
+	if o.OnMiss != nil {
+		o.OnMiss(key)
+	}
+}
+
+func (o CacheOptions) refreshed(key string, err error) {
+	if o.OnRefresh != nil {
+		o.OnRefresh(key, err)
+	}
+}
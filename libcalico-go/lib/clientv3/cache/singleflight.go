@@ -0,0 +1,160 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"sync"
+	"time"
+)
+
+// entry is one cached value plus the bookkeeping needed for TTL expiry and LRU-ish eviction.
+type entry[V any] struct {
+	value       V
+	err         error
+	lastRefresh time.Time
+}
+
+// keyedCache is a TTL cache with single-flight refresh: concurrent callers for the same key only
+// trigger one call to fn at a time. Through refresh (a missing entry) the rest wait for it and
+// then share its result; through refreshAsync (a stale entry) they return at once and keep the
+// stale value until the refresh lands. This is the same "cached counter" shape used elsewhere in this repo
+// for debouncing expensive recomputation onto one in-flight call per key.
+type keyedCache[V any] struct {
+	ttl        time.Duration
+	maxEntries int
+
+	mu      sync.Mutex
+	entries map[string]*entry[V]
+	calls   map[string]*inflightCall[V]
+}
+
+// inflightCall represents a refresh in progress for a given key; waiters block on done.
+type inflightCall[V any] struct {
+	done  chan struct{}
+	value V
+	err   error
+}
+
+func newKeyedCache[V any](ttl time.Duration, maxEntries int) *keyedCache[V] {
+	return &keyedCache[V]{
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		entries:    map[string]*entry[V]{},
+		calls:      map[string]*inflightCall[V]{},
+	}
+}
+
+// get returns the cached value for key if present, along with whether a refresh should be
+// kicked off (the entry doesn't exist yet, or it's older than the TTL).
+func (c *keyedCache[V]) get(key string) (value V, hit bool, needsRefresh bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[key]
+	if !ok {
+		return value, false, true
+	}
+	return e.value, true, time.Since(e.lastRefresh) > c.ttl
+}
+
+// refresh runs fn for key, single-flighted: if a refresh for key is already in progress, this
+// call waits for it and returns its result instead of starting a second one. On success the
+// result is stored as the new cache entry.
+func (c *keyedCache[V]) refresh(key string, fn func() (V, error)) (V, error) {
+	c.mu.Lock()
+	if call, inProgress := c.calls[key]; inProgress {
+		c.mu.Unlock()
+		<-call.done
+		return call.value, call.err
+	}
+	call := &inflightCall[V]{done: make(chan struct{})}
+	c.calls[key] = call
+	c.mu.Unlock()
+
+	call.value, call.err = fn()
+	close(call.done)
+
+	c.mu.Lock()
+	delete(c.calls, key)
+	if call.err == nil {
+		c.store(key, call.value)
+	}
+	c.mu.Unlock()
+
+	return call.value, call.err
+}
+
+// refreshAsync is like refresh but doesn't wait for fn to complete; used for the
+// stale-while-refresh path, where the caller already has a (stale) value to return.
+func (c *keyedCache[V]) refreshAsync(key string, fn func() (V, error)) {
+	c.mu.Lock()
+	if _, inProgress := c.calls[key]; inProgress {
+		c.mu.Unlock()
+		return
+	}
+	call := &inflightCall[V]{done: make(chan struct{})}
+	c.calls[key] = call
+	c.mu.Unlock()
+
+	go func() {
+		call.value, call.err = fn()
+		close(call.done)
+
+		c.mu.Lock()
+		delete(c.calls, key)
+		if call.err == nil {
+			c.store(key, call.value)
+		}
+		c.mu.Unlock()
+	}()
+}
+
+// store must be called with c.mu held.
+func (c *keyedCache[V]) store(key string, value V) {
+	if c.maxEntries > 0 && len(c.entries) >= c.maxEntries {
+		if _, exists := c.entries[key]; !exists {
+			c.evictOldestLocked()
+		}
+	}
+	c.entries[key] = &entry[V]{value: value, lastRefresh: time.Now()}
+}
+
+// evictOldestLocked must be called with c.mu held.
+func (c *keyedCache[V]) evictOldestLocked() {
+	var oldestKey string
+	var oldestAt time.Time
+	for k, e := range c.entries {
+		if oldestKey == "" || e.lastRefresh.Before(oldestAt) {
+			oldestKey, oldestAt = k, e.lastRefresh
+		}
+	}
+	if oldestKey != "" {
+		delete(c.entries, oldestKey)
+	}
+}
+
+// invalidate removes key's entry, if any, so the next get forces a synchronous refresh.
+func (c *keyedCache[V]) invalidate(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, key)
+}
+
+// invalidateAll clears every entry, used when a write can't be attributed to a single key (e.g.
+// a List cache entry keyed by selector, invalidated on any write).
+func (c *keyedCache[V]) invalidateAll() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = map[string]*entry[V]{}
+}
@@ -0,0 +1,62 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package informers builds client-go cache.SharedIndexInformers on top of clientv3's
+// per-resource List/Watch calls, the same way a generated Kubernetes clientset's
+// informers/externalversions tree builds informers on top of a typed clientset. Unlike a
+// generated tree, there's a single resource-agnostic factory (this file); each resource's own
+// file (e.g. livemigration.go) just registers a ListWatchFactory for its Kind under
+// init(), so adding BGPPeer, NetworkPolicy, etc. informers doesn't require touching this file.
+package informers
+
+import (
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/projectcalico/calico/libcalico-go/lib/clientv3"
+)
+
+// ListWatchFactory builds a cache.ListerWatcher for a single v3 resource kind from a clientv3
+// client, bridging that resource's typed List/Watch methods into the generic shape
+// cache.SharedIndexInformer consumes.
+type ListWatchFactory func(client clientv3.Interface) cache.ListerWatcher
+
+// registry maps each v3 resource kind to the ListWatchFactory that knows how to build a
+// ListerWatcher for it.
+var registry = map[string]ListWatchFactory{}
+
+// Register adds kind's ListWatchFactory to the registry. Each resource's informer file calls
+// this from its own init(); callers of NewSharedIndexInformer never need to call it directly.
+func Register(kind string, factory ListWatchFactory) {
+	registry[kind] = factory
+}
+
+// NewSharedIndexInformer builds a cache.SharedIndexInformer for kind, resyncing every
+// resyncPeriod, using the ListWatchFactory registered for kind. It panics if kind has no
+// registered factory, mirroring the behaviour of a generated clientset's informer factory when
+// asked for an unrecognised GroupVersionResource.
+func NewSharedIndexInformer(client clientv3.Interface, kind string, exampleObject runtime.Object, resyncPeriod time.Duration) cache.SharedIndexInformer {
+	factory, ok := registry[kind]
+	if !ok {
+		panic("informers: no ListWatchFactory registered for kind " + kind)
+	}
+	return cache.NewSharedIndexInformer(
+		factory(client),
+		exampleObject,
+		resyncPeriod,
+		cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc},
+	)
+}
@@ -0,0 +1,104 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package informers
+
+import (
+	"context"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kwatch "k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/projectcalico/calico/libcalico-go/lib/apis/internalapi"
+	"github.com/projectcalico/calico/libcalico-go/lib/clientv3"
+	"github.com/projectcalico/calico/libcalico-go/lib/clientv3/listers"
+	"github.com/projectcalico/calico/libcalico-go/lib/options"
+	"github.com/projectcalico/calico/libcalico-go/lib/watch"
+)
+
+func init() {
+	Register(internalapi.KindLiveMigration, func(client clientv3.Interface) cache.ListerWatcher {
+		return &cache.ListWatch{
+			ListFunc: func(_ metav1.ListOptions) (runtime.Object, error) {
+				return client.LiveMigrations().List(context.Background(), options.ListOptions{})
+			},
+			WatchFunc: func(_ metav1.ListOptions) (kwatch.Interface, error) {
+				w, err := client.LiveMigrations().Watch(context.Background(), options.ListOptions{})
+				if err != nil {
+					return nil, err
+				}
+				return toKubernetesWatch(w), nil
+			},
+		}
+	})
+}
+
+// toKubernetesWatch adapts a v3 API watch.Interface, as returned by LiveMigrations().Watch, to
+// the k8s.io/apimachinery watch.Interface that cache.SharedIndexInformer's reflector expects.
+func toKubernetesWatch(w watch.Interface) kwatch.Interface {
+	out := make(chan kwatch.Event)
+	proxy := kwatch.NewProxyWatcher(out)
+	go func() {
+		defer close(out)
+		for ev := range w.ResultChan() {
+			select {
+			case out <- kwatch.Event{Type: toKubernetesEventType(ev.Type), Object: ev.Object}:
+			case <-proxy.StopChan():
+				w.Stop()
+				return
+			}
+		}
+	}()
+	return proxy
+}
+
+func toKubernetesEventType(t watch.EventType) kwatch.EventType {
+	switch t {
+	case watch.Added:
+		return kwatch.Added
+	case watch.Modified:
+		return kwatch.Modified
+	case watch.Deleted:
+		return kwatch.Deleted
+	default:
+		return kwatch.Error
+	}
+}
+
+// LiveMigrationInformer provides access to a shared informer and lister for LiveMigrations.
+type LiveMigrationInformer struct {
+	informer cache.SharedIndexInformer
+}
+
+// NewLiveMigrationInformer builds a LiveMigrationInformer backed by client, resyncing its store
+// from a full List every resyncPeriod in addition to the ongoing Watch.
+func NewLiveMigrationInformer(client clientv3.Interface, resyncPeriod time.Duration) *LiveMigrationInformer {
+	return &LiveMigrationInformer{
+		informer: NewSharedIndexInformer(client, internalapi.KindLiveMigration, &internalapi.LiveMigration{}, resyncPeriod),
+	}
+}
+
+// Informer returns the underlying cache.SharedIndexInformer, for callers that need to register
+// event handlers or drive it with Run/HasSynced directly.
+func (i *LiveMigrationInformer) Informer() cache.SharedIndexInformer {
+	return i.informer
+}
+
+// Lister returns a listers.LiveMigrationLister reading from this informer's store.
+func (i *LiveMigrationInformer) Lister() listers.LiveMigrationLister {
+	return listers.NewLiveMigrationLister(i.informer.GetIndexer())
+}
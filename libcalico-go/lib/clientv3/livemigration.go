@@ -16,18 +16,22 @@ package clientv3
 
 import (
 	"context"
+	"fmt"
+	"reflect"
 
 	"github.com/projectcalico/calico/libcalico-go/lib/apis/internalapi"
-	cerrors "github.com/projectcalico/calico/libcalico-go/lib/errors"
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/model"
 	"github.com/projectcalico/calico/libcalico-go/lib/options"
 	validator "github.com/projectcalico/calico/libcalico-go/lib/validator/v3"
 	"github.com/projectcalico/calico/libcalico-go/lib/watch"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 // LiveMigrationInterface has methods to work with LiveMigration resources.
 type LiveMigrationInterface interface {
 	Create(ctx context.Context, res *internalapi.LiveMigration, opts options.SetOptions) (*internalapi.LiveMigration, error)
 	Update(ctx context.Context, res *internalapi.LiveMigration, opts options.SetOptions) (*internalapi.LiveMigration, error)
+	UpdateStatus(ctx context.Context, res *internalapi.LiveMigration, opts options.SetOptions) (*internalapi.LiveMigration, error)
 	Delete(ctx context.Context, namespace, name string, opts options.DeleteOptions) (*internalapi.LiveMigration, error)
 	Get(ctx context.Context, namespace, name string, opts options.GetOptions) (*internalapi.LiveMigration, error)
 	List(ctx context.Context, opts options.ListOptions) (*internalapi.LiveMigrationList, error)
@@ -53,13 +57,48 @@ func (r liveMigrations) Create(ctx context.Context, res *internalapi.LiveMigrati
 	return nil, err
 }
 
-// Update takes the representation of a LiveMigration and updates it.  Returns the stored
-// representation of the LiveMigration, and an error, if there is any.
+// Update takes the representation of a LiveMigration and updates its spec.  It rejects the
+// update if res.Status differs from the currently stored object's status — use UpdateStatus for
+// that instead, the same split Kubernetes makes between a resource's main endpoint and its
+// /status subresource.  Returns the stored representation of the LiveMigration, and an error, if
+// there is any.  A stale res.ResourceVersion surfaces as a typed ErrorResourceUpdateConflict, the
+// same as for any other writable v3 resource, so controllers know to re-Get and requeue.
 func (r liveMigrations) Update(ctx context.Context, res *internalapi.LiveMigration, opts options.SetOptions) (*internalapi.LiveMigration, error) {
 	if err := validator.Validate(res); err != nil {
 		return nil, err
 	}
 
+	current, err := r.Get(ctx, res.Namespace, res.Name, options.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if !reflect.DeepEqual(current.Status, res.Status) {
+		return nil, fmt.Errorf("cannot change LiveMigration status via Update; use UpdateStatus instead")
+	}
+
+	opts.SubResource = ""
+	out, err := r.client.resources.Update(ctx, opts, internalapi.KindLiveMigration, res)
+	if out != nil {
+		return out.(*internalapi.LiveMigration), err
+	}
+	return nil, err
+}
+
+// UpdateStatus takes the representation of a LiveMigration and updates only its status, via the
+// /status subresource, leaving its spec untouched — see Update.  It rejects the update if
+// res.Spec differs from the currently stored object's spec.  Returns the stored representation
+// of the LiveMigration, and an error, if there is any; as with Update, a stale
+// res.ResourceVersion surfaces as a typed ErrorResourceUpdateConflict.
+func (r liveMigrations) UpdateStatus(ctx context.Context, res *internalapi.LiveMigration, opts options.SetOptions) (*internalapi.LiveMigration, error) {
+	current, err := r.Get(ctx, res.Namespace, res.Name, options.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if !reflect.DeepEqual(current.Spec, res.Spec) {
+		return nil, fmt.Errorf("cannot change LiveMigration spec via UpdateStatus; use Update instead")
+	}
+
+	opts.SubResource = "status"
 	out, err := r.client.resources.Update(ctx, opts, internalapi.KindLiveMigration, res)
 	if out != nil {
 		return out.(*internalapi.LiveMigration), err
@@ -97,23 +136,36 @@ func (r liveMigrations) List(ctx context.Context, opts options.ListOptions) (*in
 
 // Watch returns a watch.Interface that watches the LiveMigrations that match the
 // supplied options.
+//
+// In Kubernetes, where a LiveMigration resource doesn't have its own storage but is instead
+// backed by the KubeVirt VirtualMachineInstanceMigration (VMIM) resource with the same name and
+// namespace, we have implemented the conversion such that the emitted LiveMigration KV pair has
+// `Value == nil` when the VirtualMachineInstanceMigration is in a state that Felix can treat
+// equivalently to the LiveMigration not existing.  convertEvent (see watcher.go) turns that
+// nil-Value transition into a synthetic watch.Deleted carrying just the LiveMigration's identity,
+// and the reverse transition into a watch.Added, so Typha and Felix see ordinary Kubernetes-style
+// watch semantics without needing to know about the VMIM-backed storage underneath.
 func (r liveMigrations) Watch(ctx context.Context, opts options.ListOptions) (watch.Interface, error) {
-	// In Kubernetes, where a LiveMigration resource doesn't have its own storage but is instead
-	// backed by the KubeVirt VirtualMachineInstanceMigration (VMIM) resource with the same name
-	// and namespace, we have implemented the conversion such that the emitted LiveMigration KV
-	// pair has `Value == nil` when the VirtualMachineInstanceMigration is in a state that Felix
-	// can treat equivalently to the LiveMigration not existing.  Typha and Felix handle this
-	// well, i.e. as though the LiveMigration has been deleted.  (And correspondingly, if the
-	// VMIM then transitions to a state of interest, as though the LiveMigration has been
-	// created again.)
-	//
-	// However the v3 API Watch machinery does not currently handle `Value == nil`.
-	// Specifically, `convertEvent` calls `w.client.kvPairToResource(backendEvent.New)`, and
-	// `kvPairToResource` will panic in that case.  Hence we document and firewall against this
-	// here.
-	return nil, cerrors.ErrorOperationNotSupported{
-		Operation:  "Watch",
-		Identifier: internalapi.KindLiveMigration,
-		Reason:     "Watch is not supported for LiveMigration resources",
+	backend, err := r.client.resources.Watch(ctx, opts, internalapi.KindLiveMigration)
+	if err != nil {
+		return nil, err
 	}
+	return newKVPairWatcher(backend, liveMigrationToResource, liveMigrationIdentity), nil
+}
+
+// liveMigrationToResource converts a KVPair with a non-nil Value into its *internalapi.LiveMigration
+// representation. convertEvent only calls this once it has established kvp.Value is non-nil.
+func liveMigrationToResource(kvp *model.KVPair) (runtime.Object, error) {
+	return kvp.Value.(*internalapi.LiveMigration), nil
+}
+
+// liveMigrationIdentity builds a LiveMigration carrying just the identifying metadata for key,
+// for use in the synthetic watch.Deleted event convertEvent emits when a LiveMigration's Value
+// has gone nil.
+func liveMigrationIdentity(key model.ResourceKey, revision string) runtime.Object {
+	lm := internalapi.NewLiveMigration()
+	lm.Namespace = key.Namespace
+	lm.Name = key.Name
+	lm.ResourceVersion = revision
+	return lm
 }
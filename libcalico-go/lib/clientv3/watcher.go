@@ -0,0 +1,132 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clientv3
+
+import (
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/api"
+	"github.com/projectcalico/calico/libcalico-go/lib/backend/model"
+	"github.com/projectcalico/calico/libcalico-go/lib/watch"
+)
+
+// kvPairToResourceFunc converts a non-nil-Value model.KVPair into its v3 API resource
+// representation. It's always safe to type-assert kvp.Value here: callers only invoke it when
+// convertEvent has already established that the Value is non-nil.
+type kvPairToResourceFunc func(kvp *model.KVPair) (runtime.Object, error)
+
+// resourceIdentityFunc builds a v3 API resource carrying just the identifying metadata
+// (namespace/name/resource version) for a key whose Value has gone nil, i.e. for the
+// watch.Deleted event convertEvent synthesizes in that case.
+type resourceIdentityFunc func(key model.ResourceKey, revision string) runtime.Object
+
+// convertEvent translates a single backend api.WatchEvent into a v3 API watch.Event.
+//
+// Backends are allowed to emit a KVPair whose Value is nil when the resource they're watching
+// still identifies a key but is, for that backend's purposes, "not of interest" right now. The
+// Kubernetes LiveMigration backend does exactly this for VirtualMachineInstanceMigrations that
+// are in a phase Felix should treat as "this LiveMigration doesn't exist" (see
+// vmimShouldEmitLiveMigration in backend/k8s/resources/livemigration.go). convertEvent turns the
+// nil/non-nil transition of New.Value (relative to Old.Value) into the watch.Event a consumer
+// actually wants:
+//
+//   - Old has no value (or there was no previous event) and New has one: watch.Added
+//   - Old had a value and New has none: watch.Deleted, with an identity-only object built via
+//     toIdentity, since there's no spec left to report
+//   - neither has a value: suppressed; ok is false and nothing should be forwarded
+//   - both have a value: watch.Modified, or watch.Added if the backend already labelled it ADDED
+//     (e.g. during the initial list-then-watch sync)
+//
+// This replaces the previous behaviour of unconditionally calling toResource(ev.New), which
+// panicked on a nil Value.
+func convertEvent(ev api.WatchEvent, toResource kvPairToResourceFunc, toIdentity resourceIdentityFunc) (watch.Event, bool) {
+	if ev.Type == api.WatchError {
+		return watch.Event{Type: watch.Error, Error: ev.Error}, true
+	}
+
+	oldHasValue := ev.Old != nil && ev.Old.Value != nil
+	newHasValue := ev.New != nil && ev.New.Value != nil
+
+	switch {
+	case !oldHasValue && !newHasValue:
+		return watch.Event{}, false
+	case !oldHasValue && newHasValue:
+		obj, err := toResource(ev.New)
+		if err != nil {
+			return watch.Event{Type: watch.Error, Error: err}, true
+		}
+		return watch.Event{Type: watch.Added, Object: obj}, true
+	case oldHasValue && !newHasValue:
+		// A backend's own delete event carries only Old, so take the identity from whichever
+		// KVPair is present.
+		ident := ev.New
+		if ident == nil {
+			ident = ev.Old
+		}
+		key := ident.Key.(model.ResourceKey)
+		return watch.Event{Type: watch.Deleted, Object: toIdentity(key, ident.Revision)}, true
+	default:
+		obj, err := toResource(ev.New)
+		if err != nil {
+			return watch.Event{Type: watch.Error, Error: err}, true
+		}
+		eventType := watch.Modified
+		if ev.Type == api.WatchAdded {
+			eventType = watch.Added
+		}
+		return watch.Event{Type: eventType, Object: obj}, true
+	}
+}
+
+// kvPairWatcher adapts a backend api.WatchInterface into the v3 API's watch.Interface, using
+// convertEvent to apply the nil-Value handling described above.
+type kvPairWatcher struct {
+	backend    api.WatchInterface
+	toResource kvPairToResourceFunc
+	toIdentity resourceIdentityFunc
+	out        chan watch.Event
+}
+
+// newKVPairWatcher starts translating backend to its v3 equivalent in a background goroutine.
+// The returned watch.Interface's ResultChan is closed once backend's is.
+func newKVPairWatcher(backend api.WatchInterface, toResource kvPairToResourceFunc, toIdentity resourceIdentityFunc) watch.Interface {
+	w := &kvPairWatcher{
+		backend:    backend,
+		toResource: toResource,
+		toIdentity: toIdentity,
+		out:        make(chan watch.Event),
+	}
+	go w.run()
+	return w
+}
+
+func (w *kvPairWatcher) run() {
+	defer close(w.out)
+	for ev := range w.backend.ResultChan() {
+		converted, ok := convertEvent(ev, w.toResource, w.toIdentity)
+		if !ok {
+			continue
+		}
+		w.out <- converted
+	}
+}
+
+func (w *kvPairWatcher) Stop() {
+	w.backend.Stop()
+}
+
+func (w *kvPairWatcher) ResultChan() <-chan watch.Event {
+	return w.out
+}